@@ -0,0 +1,11 @@
+data "googleworkspace_admin_effective_privileges" "dwight" {
+  user_key                  = "dwight.schrute@example.com"
+  include_group_assignments = true
+}
+
+output "dwight_org_unit_scoped_privileges" {
+  value = [
+    for priv in data.googleworkspace_admin_effective_privileges.dwight.privileges : priv.privilege_name
+    if alltrue([for grant in priv.grants : grant.scope_type == "ORG_UNIT"])
+  ]
+}
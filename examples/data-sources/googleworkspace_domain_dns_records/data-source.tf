@@ -0,0 +1,32 @@
+data "googleworkspace_domain_dns_records" "example" {
+  domain_name        = "example.com"
+  dkim_public_key    = "MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA..."
+  dmarc_policy       = "quarantine"
+  dmarc_report_email = "dmarc-reports@example.com"
+
+  check_records = true
+  resolver      = "8.8.8.8:53"
+}
+
+locals {
+  mx_records = [
+    for record in data.googleworkspace_domain_dns_records.example.records : record
+    if record.type == "MX"
+  ]
+}
+
+# any DNS provider, e.g. Cloud DNS
+resource "google_dns_record_set" "mx" {
+  managed_zone = "example-com"
+  name         = "example.com."
+  type         = "MX"
+  ttl          = 3600
+  rrdatas      = [for record in local.mx_records : "${record.priority} ${record.value}."]
+}
+
+output "records_to_fix" {
+  value = [
+    for record in data.googleworkspace_domain_dns_records.example.records : "${record.name} (${record.purpose}): ${record.status}"
+    if record.status != "ok"
+  ]
+}
@@ -0,0 +1,8 @@
+data "googleworkspace_domain_verification_token" "example" {
+  domain_name         = "example.com"
+  verification_method = "DNS_CNAME"
+}
+
+output "verification_record" {
+  value = "${data.googleworkspace_domain_verification_token.example.record_name} CNAME ${data.googleworkspace_domain_verification_token.example.record_value}"
+}
@@ -3,4 +3,22 @@ data "googleworkspace_groups" "my-domain-groups" {
 
 output "num_groups" {
   value = length(data.googleworkspace_groups.my-domain-groups.groups)
-}
\ No newline at end of file
+}
+
+# List the groups a user belongs to
+data "googleworkspace_groups" "user-groups" {
+  user_key = "user@example.com"
+}
+
+# Find all groups that anyone on the internet can join
+data "googleworkspace_groups" "audit" {
+  domain           = "example.com"
+  include_settings = true
+}
+
+output "externally_joinable_groups" {
+  value = [
+    for g in data.googleworkspace_groups.audit.groups : g.email
+    if g.settings[0].who_can_join == "ANYONE_CAN_JOIN"
+  ]
+}
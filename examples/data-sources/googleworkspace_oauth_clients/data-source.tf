@@ -0,0 +1,12 @@
+data "googleworkspace_oauth_clients" "sales" {
+  query       = "orgUnitPath='/Sales'"
+  concurrency = 20
+}
+
+# Find the applications granted access to Drive
+output "drive_clients" {
+  value = {
+    for c in data.googleworkspace_oauth_clients.sales.clients : c.display_text => c.user_count
+    if contains(c.scopes, "https://www.googleapis.com/auth/drive")
+  }
+}
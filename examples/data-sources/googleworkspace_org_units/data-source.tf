@@ -0,0 +1,20 @@
+data "googleworkspace_org_units" "all" {
+  include_counts = true
+
+  # resolves the effective values for every org unit
+  policy_schemas = ["chrome.users.IncognitoModeAvailability"]
+}
+
+output "empty_org_units" {
+  value = [
+    for ou in data.googleworkspace_org_units.all.org_units : ou.org_unit_path
+    if ou.user_count == 0 && ou.device_count == 0
+  ]
+}
+
+output "incognito_overrides" {
+  value = [
+    for ou in data.googleworkspace_org_units.all.org_units : ou.org_unit_path
+    if anytrue([for p in ou.policies : !p.inherited])
+  ]
+}
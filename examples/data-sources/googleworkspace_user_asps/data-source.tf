@@ -0,0 +1,7 @@
+data "googleworkspace_user_asps" "jim" {
+  user_id = "jim.halpert@example.com"
+}
+
+output "jim_unused_asps" {
+  value = [for a in data.googleworkspace_user_asps.jim.asps : a.name if a.last_time_used == ""]
+}
@@ -0,0 +1,7 @@
+data "googleworkspace_user_tokens" "jim" {
+  user_id = "jim.halpert@example.com"
+}
+
+output "jim_authorized_apps" {
+  value = [for t in data.googleworkspace_user_tokens.jim.tokens : t.display_text]
+}
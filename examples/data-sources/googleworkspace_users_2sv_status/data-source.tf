@@ -0,0 +1,14 @@
+data "googleworkspace_users_2sv_status" "sales" {
+  org_unit_path = "/Sales"
+}
+
+output "sales_users_without_2sv" {
+  value = data.googleworkspace_users_2sv_status.sales.not_enrolled_users
+}
+
+check "sales_2sv_enrollment" {
+  assert {
+    condition     = data.googleworkspace_users_2sv_status.sales.not_enrolled_count == 0
+    error_message = "All users in /Sales must be enrolled in 2-Step Verification."
+  }
+}
@@ -0,0 +1 @@
+terraform import googleworkspace_domain_verification.example example.com
@@ -0,0 +1,27 @@
+resource "googleworkspace_domain" "example" {
+  domain_name = "example.com"
+}
+
+data "googleworkspace_domain_verification_token" "example" {
+  domain_name = googleworkspace_domain.example.domain_name
+}
+
+# any DNS provider, e.g. Cloud DNS
+resource "google_dns_record_set" "verification" {
+  managed_zone = "example-com"
+  name         = "${data.googleworkspace_domain_verification_token.example.record_name}."
+  type         = data.googleworkspace_domain_verification_token.example.record_type
+  ttl          = 300
+  rrdatas      = ["\"${data.googleworkspace_domain_verification_token.example.record_value}\""]
+}
+
+resource "googleworkspace_domain_verification" "example" {
+  domain_name = googleworkspace_domain.example.domain_name
+
+  # the record has to exist before the domain is verified
+  depends_on = [google_dns_record_set.verification]
+
+  timeouts {
+    create = "60m"
+  }
+}
@@ -0,0 +1 @@
+terraform import googleworkspace_gmail_delegate.example user@example.com/assistant@example.com
\ No newline at end of file
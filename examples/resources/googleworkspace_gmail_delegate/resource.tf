@@ -0,0 +1,12 @@
+data "googleworkspace_user" "example" {
+  primary_email = "user.with.gmail.license@example.com"
+}
+
+data "googleworkspace_user" "assistant" {
+  primary_email = "assistant.with.gmail.license@example.com"
+}
+
+resource "googleworkspace_gmail_delegate" "example" {
+  primary_email  = data.googleworkspace_user.example.primary_email
+  delegate_email = data.googleworkspace_user.assistant.primary_email
+}
@@ -0,0 +1 @@
+terraform import googleworkspace_gmail_delegates.example user@example.com
\ No newline at end of file
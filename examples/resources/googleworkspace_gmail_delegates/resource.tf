@@ -0,0 +1,15 @@
+data "googleworkspace_user" "example" {
+  primary_email = "user.with.gmail.license@example.com"
+}
+
+resource "googleworkspace_gmail_delegates" "example" {
+  primary_email = data.googleworkspace_user.example.primary_email
+
+  delegates = [
+    "assistant@example.com",
+    "backup.assistant@example.com",
+  ]
+
+  # delegates may accept the delegation after the apply
+  wait_for_acceptance = false
+}
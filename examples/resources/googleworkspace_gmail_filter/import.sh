@@ -0,0 +1 @@
+terraform import googleworkspace_gmail_filter.invoices user@example.com/ANe1BmjbWyo8ZeUiC7OVYvhAe5FxCzmBNm0HHQ
\ No newline at end of file
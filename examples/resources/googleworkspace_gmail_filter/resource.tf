@@ -0,0 +1,24 @@
+data "googleworkspace_user" "example" {
+  primary_email = "user.with.gmail.license@example.com"
+}
+
+resource "googleworkspace_gmail_forwarding_address" "archive" {
+  primary_email    = data.googleworkspace_user.example.primary_email
+  forwarding_email = "archive@example.com"
+}
+
+# archive invoices and forward them to the archive address
+resource "googleworkspace_gmail_filter" "invoices" {
+  primary_email = data.googleworkspace_user.example.primary_email
+
+  criteria {
+    from           = "billing@vendor.example.com"
+    has_attachment = true
+  }
+
+  action {
+    add_label_ids    = ["STARRED"]
+    remove_label_ids = ["INBOX"]
+    forward          = googleworkspace_gmail_forwarding_address.archive.forwarding_email
+  }
+}
@@ -0,0 +1 @@
+terraform import googleworkspace_gmail_forwarding_address.example user@example.com/archive@example.com
\ No newline at end of file
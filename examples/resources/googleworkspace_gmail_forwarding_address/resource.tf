@@ -0,0 +1,8 @@
+data "googleworkspace_user" "example" {
+  primary_email = "user.with.gmail.license@example.com"
+}
+
+resource "googleworkspace_gmail_forwarding_address" "example" {
+  primary_email    = data.googleworkspace_user.example.primary_email
+  forwarding_email = "archive@example.com"
+}
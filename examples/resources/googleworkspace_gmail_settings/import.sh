@@ -0,0 +1 @@
+terraform import googleworkspace_gmail_settings.offboarding departing.user@example.com
\ No newline at end of file
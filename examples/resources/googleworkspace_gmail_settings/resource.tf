@@ -0,0 +1,27 @@
+data "googleworkspace_user" "departing" {
+  primary_email = "departing.user@example.com"
+}
+
+# only the configured settings are managed, the display language is left alone
+resource "googleworkspace_gmail_settings" "offboarding" {
+  primary_email = data.googleworkspace_user.departing.primary_email
+
+  vacation {
+    enable_auto_reply        = true
+    response_subject         = "I have left Example Inc."
+    response_body_plain_text = "Please contact support@example.com instead."
+    start_time               = "2024-07-01T09:00:00+02:00"
+  }
+
+  imap {
+    enabled = false
+  }
+
+  pop {
+    access_window = "disabled"
+  }
+
+  auto_forwarding {
+    enabled = false
+  }
+}
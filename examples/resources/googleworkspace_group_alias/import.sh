@@ -0,0 +1 @@
+terraform import googleworkspace_group_alias.deals groups/01abcde23fg4h5i/aliases/deals@example.com
\ No newline at end of file
@@ -0,0 +1,9 @@
+resource "googleworkspace_group" "sales" {
+  email          = "sales@example.com"
+  manage_aliases = false
+}
+
+resource "googleworkspace_group_alias" "deals" {
+  group_id = googleworkspace_group.sales.id
+  alias    = "deals@example.com"
+}
@@ -0,0 +1 @@
+terraform import googleworkspace_org_unit_members.sales /Sales
@@ -0,0 +1,20 @@
+resource "googleworkspace_org_unit" "sales" {
+  name                 = "Sales"
+  parent_org_unit_path = "/"
+}
+
+resource "googleworkspace_org_unit_members" "sales" {
+  org_unit_path = googleworkspace_org_unit.sales.org_unit_path
+
+  users = [
+    "michael.scott@example.com",
+    "dwight.schrute@example.com",
+    "jim.halpert@example.com",
+  ]
+
+  manage_devices = true
+  device_ids     = ["a1b2c3d4-0000-0000-0000-000000000000"]
+
+  # members removed from the org unit are moved here
+  fallback_org_unit_path = "/Unassigned"
+}
@@ -0,0 +1,24 @@
+resource "googleworkspace_org_unit_tree" "company" {
+  # users of deleted org units are moved here instead of failing the delete
+  fallback_org_unit_path = "/Unassigned"
+
+  org_units {
+    path        = "/Scranton"
+    description = "Scranton branch"
+  }
+
+  org_units {
+    path = "/Scranton/Sales"
+  }
+
+  # renamed from /Scranton/Accounting, keeping its users and settings
+  org_units {
+    path       = "/Scranton/Finance"
+    moved_from = "/Scranton/Accounting"
+  }
+
+  org_units {
+    path              = "/Scranton/Warehouse"
+    block_inheritance = true
+  }
+}
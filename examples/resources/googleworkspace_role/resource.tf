@@ -17,4 +17,20 @@ resource "googleworkspace_role" "read-only" {
       privilege_name = privileges.value["privilege_name"]
     }
   }
-}
\ No newline at end of file
+}
+# grants USERS_RETRIEVE along with all of its child privileges
+resource "googleworkspace_role" "user-reader" {
+  name            = "user-reader"
+  expand_children = true
+
+  dynamic "privileges" {
+    for_each = [
+      for priv in data.googleworkspace_privileges.privileges.items : priv
+      if priv.privilege_name == "USERS_RETRIEVE"
+    ]
+    content {
+      service_id     = privileges.value["service_id"]
+      privilege_name = privileges.value["privilege_name"]
+    }
+  }
+}
@@ -1 +1,4 @@
-terraform import googleworkspace_role_assignment.dwight 12345678901234567
\ No newline at end of file
+terraform import googleworkspace_role_assignment.dwight 12345678901234567
+
+# role assignments can also be imported by role ID, assignee and scope, either CUSTOMER or an org unit ID
+terraform import googleworkspace_role_assignment.helpdesk 12345678901234567/helpdesk@example.com/CUSTOMER
\ No newline at end of file
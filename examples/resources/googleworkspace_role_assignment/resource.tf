@@ -51,4 +51,24 @@ resource "googleworkspace_role_assignment" "org-unit-scope" {
   assigned_to = googleworkspace_user.dwight.id
   scope_type  = "ORG_UNIT"
   org_unit_id = googleworkspace_user.org-unit.id
-}
\ No newline at end of file
+}
+# groups are assigned by email address, a condition can restrict the role to security groups
+
+data "googleworkspace_role" "groups-editor" {
+  name = "_GROUPS_EDITOR_ROLE"
+}
+
+resource "googleworkspace_role_assignment" "helpdesk" {
+  role_id       = data.googleworkspace_role.groups-editor.id
+  assigned_to   = "helpdesk@example.com"
+  assignee_type = "GROUP"
+  condition     = "api.getAttribute('cloudidentity.googleapis.com/groups.labels', []).hasAny(['groups.security']) && resource.type == 'cloudidentity.googleapis.com/Group'"
+}
+
+# service accounts are assigned by their unique ID
+
+resource "googleworkspace_role_assignment" "automation" {
+  role_id       = data.googleworkspace_role.groups-admin.id
+  assigned_to   = "112233445566778899001"
+  assignee_type = "SERVICE_ACCOUNT"
+}
@@ -0,0 +1,4 @@
+# role assignments of a role in the customer scope are imported as <role_id>/CUSTOMER
+
+terraform import googleworkspace_role_assignments.super-admins 12345678901234567/CUSTOMER
+terraform import googleworkspace_role_assignments.sales-groups-editors 12345678901234567/03ph8a2z1enx4lx
\ No newline at end of file
@@ -0,0 +1,40 @@
+data "googleworkspace_role" "super-admin" {
+  name = "_SEED_ADMIN_ROLE"
+}
+
+# assignments of the role made in the Admin console are removed on the next apply
+resource "googleworkspace_role_assignments" "super-admins" {
+  role_id = data.googleworkspace_role.super-admin.id
+
+  assignees {
+    assigned_to = "michael.scott@example.com"
+  }
+
+  assignees {
+    assigned_to   = "it-admins@example.com"
+    assignee_type = "GROUP"
+  }
+
+  # the break-glass account keeps the role even if it's missing from assignees
+  protected_assignees = ["breakglass@example.com"]
+}
+
+data "googleworkspace_role" "groups-editor" {
+  name = "_GROUPS_EDITOR_ROLE"
+}
+
+resource "googleworkspace_org_unit" "sales" {
+  name                 = "Sales"
+  parent_org_unit_path = "/"
+}
+
+resource "googleworkspace_role_assignments" "sales-groups-editors" {
+  role_id     = data.googleworkspace_role.groups-editor.id
+  scope_type  = "ORG_UNIT"
+  org_unit_id = googleworkspace_org_unit.sales.id
+
+  assignees {
+    assigned_to = "dwight.schrute@example.com"
+    condition   = "api.getAttribute('cloudidentity.googleapis.com/groups.labels', []).hasAny(['groups.security']) && resource.type == 'cloudidentity.googleapis.com/Group'"
+  }
+}
@@ -5,4 +5,23 @@ resource "googleworkspace_schema" "birthday" {
     field_name = "birthday"
     field_type = "DATE"
   }
-}
\ No newline at end of file
+}
+resource "googleworkspace_schema" "employment" {
+  schema_name = "employment"
+
+  # removing a field or changing its type loses the values of the users for it,
+  # which fails to plan unless allowed
+  allow_destructive_changes = false
+  count_affected_users      = true
+
+  fields {
+    field_name = "start_date"
+    field_type = "DATE"
+  }
+
+  fields {
+    field_name       = "badge_number"
+    field_type       = "STRING"
+    read_access_type = "ADMINS_AND_SELF"
+  }
+}
@@ -0,0 +1,9 @@
+# Let a user who lost their phone sign in again
+resource "googleworkspace_two_step_verification" "stanley" {
+  user_id = "stanley.hudson@example.com"
+
+  # change the ticket to turn 2SV off again
+  triggers = {
+    ticket = "HELP-5678"
+  }
+}
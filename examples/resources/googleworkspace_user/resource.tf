@@ -87,4 +87,56 @@ resource "googleworkspace_user" "dwight" {
   }
 
   recovery_email = "dwightkschrute@example.com"
-}
\ No newline at end of file
+}
+
+resource "googleworkspace_user" "ryan" {
+  primary_email = "ryan.howard@example.com"
+  password      = "34819d7beeabb9260a5c854bc85b3e44"
+  hash_function = "MD5"
+
+  name {
+    family_name = "Howard"
+    given_name  = "Ryan"
+  }
+
+  # On destroy, hand Ryan's Drive and Calendar data to Dwight before deleting the account
+  deletion_policy    = "TRANSFER_THEN_DELETE"
+  transfer_recipient = googleworkspace_user.dwight.primary_email
+}
+
+resource "googleworkspace_user" "kelly" {
+  primary_email = "kelly.kapoor@example.com"
+
+  # Only a salted hash of the password is stored in the state, and the
+  # password is hashed with SHA-512 crypt before it is sent to Google
+  password            = "Acc0unt1ng!"
+  password_write_only = true
+  hash_function       = "crypt"
+  hash_password       = true
+
+  name {
+    family_name = "Kapoor"
+    given_name  = "Kelly"
+  }
+}
+
+resource "googleworkspace_user" "toby" {
+  primary_email = "toby.flenderson@example.com"
+  password      = "34819d7beeabb9260a5c854bc85b3e44"
+  hash_function = "MD5"
+
+  name {
+    family_name = "Flenderson"
+    given_name  = "Toby"
+  }
+
+  # Organizations and phones are synced from the HR system, so they are only
+  # set when the user is created and are never updated by Terraform
+  organizations {
+    department = "HR"
+    primary    = true
+    type       = "work"
+  }
+
+  unmanaged_attributes = ["organizations", "phones"]
+}
@@ -0,0 +1 @@
+terraform import googleworkspace_user_alias.assistant_regional_manager users/123456789012345678901/aliases/assistant.regional.manager@example.com
\ No newline at end of file
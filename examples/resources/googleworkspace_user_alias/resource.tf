@@ -0,0 +1,16 @@
+resource "googleworkspace_user" "dwight" {
+  primary_email  = "dwight.schrute@example.com"
+  password       = "34819d7beeabb9260a5c854bc85b3e44"
+  hash_function  = "MD5"
+  manage_aliases = false
+
+  name {
+    family_name = "Schrute"
+    given_name  = "Dwight"
+  }
+}
+
+resource "googleworkspace_user_alias" "assistant_regional_manager" {
+  user_id = googleworkspace_user.dwight.id
+  alias   = "assistant.regional.manager@example.com"
+}
@@ -0,0 +1 @@
+terraform import googleworkspace_user_custom_schema_values.dwight_employment users/123456789012345678901/schemas/Employment
@@ -0,0 +1,38 @@
+resource "googleworkspace_schema" "employment" {
+  schema_name = "Employment"
+
+  fields {
+    field_name = "EmployeeNumber"
+    field_type = "INT64"
+  }
+
+  fields {
+    field_name   = "Projects"
+    field_type   = "STRING"
+    multi_valued = true
+  }
+}
+
+resource "googleworkspace_user" "dwight" {
+  primary_email = "dwight.schrute@example.com"
+  password      = "34819d7beeabb9260a5c854bc85b3e44"
+  hash_function = "MD5"
+
+  name {
+    family_name = "Schrute"
+    given_name  = "Dwight"
+  }
+
+  # The Employment schema is owned by the HRIS integration below
+  ignored_custom_schemas = [googleworkspace_schema.employment.schema_name]
+}
+
+resource "googleworkspace_user_custom_schema_values" "dwight_employment" {
+  user_id     = googleworkspace_user.dwight.id
+  schema_name = googleworkspace_schema.employment.schema_name
+
+  schema_values = {
+    "EmployeeNumber" = jsonencode(1234)
+    "Projects"       = jsonencode(["beets", "paper"])
+  }
+}
@@ -0,0 +1,15 @@
+resource "googleworkspace_user" "pam" {
+  primary_email = "pam.beesly@example.com"
+  password      = "34819d7beeabb9260a5c854bc85b3e44"
+  hash_function = "MD5"
+
+  name {
+    family_name = "Beesly"
+    given_name  = "Pam"
+  }
+}
+
+resource "googleworkspace_user_photo" "pam" {
+  user_id   = googleworkspace_user.pam.id
+  file_path = "${path.module}/photos/pam.jpg"
+}
@@ -0,0 +1,13 @@
+resource "googleworkspace_user_security_action" "incident" {
+  user_id = "jim.halpert@example.com"
+
+  sign_out                  = true
+  revoke_tokens             = true
+  revoke_asps               = true
+  rotate_verification_codes = true
+
+  # change the ticket to run the actions again
+  triggers = {
+    ticket = "INC-1234"
+  }
+}
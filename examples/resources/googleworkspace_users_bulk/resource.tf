@@ -0,0 +1,40 @@
+# Records exported from the HR system, e.g.
+#
+# primary_email,given_name,family_name,org_unit_path,Employment.EmployeeNumber
+# dwight.schrute@example.com,Dwight,Schrute,/sales,42
+resource "googleworkspace_users_bulk" "hr" {
+  source_file = "${path.module}/users.csv"
+  concurrency = 20
+}
+
+resource "googleworkspace_users_bulk" "interns" {
+  users {
+    primary_email = "ryan.howard@example.com"
+    password      = "34819d7beeabb9260a5c854bc85b3e44"
+    hash_function = "MD5"
+    given_name    = "Ryan"
+    family_name   = "Howard"
+    org_unit_path = "/interns"
+  }
+
+  users {
+    primary_email = "erin.hannon@example.com"
+    password      = "34819d7beeabb9260a5c854bc85b3e44"
+    hash_function = "MD5"
+    given_name    = "Erin"
+    family_name   = "Hannon"
+    org_unit_path = "/interns"
+
+    custom_schemas {
+      schema_name = "Employment"
+
+      schema_values = {
+        "EmployeeNumber" = jsonencode(43)
+      }
+    }
+  }
+}
+
+output "failed_users" {
+  value = [for email, status in googleworkspace_users_bulk.hr.status : email if status == "FAILED"]
+}
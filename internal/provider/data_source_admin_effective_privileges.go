@@ -0,0 +1,378 @@
+package googleworkspace
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataSourceAdminEffectivePrivileges() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Admin Effective Privileges data source returns what an admin can do: the role assignments " +
+			"of a user, and the privileges they grant, annotated with the granting roles and scopes. " +
+			"Admin Effective Privileges resides under the " +
+			"`https://www.googleapis.com/auth/admin.directory.rolemanagement` client scope, and org unit paths " +
+			"require the `https://www.googleapis.com/auth/admin.directory.orgunit` client scope.",
+
+		ReadContext: dataSourceAdminEffectivePrivilegesRead,
+
+		Schema: map[string]*schema.Schema{
+			"user_key": {
+				Description: "The primary email address, alias email address, or unique user ID of the user.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"include_group_assignments": {
+				Description: "If `true`, the roles assigned to the groups the user is a member of, " +
+					"directly or transitively, are included.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"is_super_admin": {
+				Description: "Whether the user is assigned a super admin role, which grants all privileges " +
+					"whether they are listed in `privileges` or not.",
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"role_assignments": {
+				Description: "The role assignments of the user, sorted by ID.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_assignment_id": {
+							Description: "The ID of the role assignment.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"role_id": {
+							Description: "The ID of the assigned role.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"role_name": {
+							Description: "The name of the assigned role.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"assigned_to": {
+							Description: "The unique ID of the entity the role is assigned to, either the user or " +
+								"a group the user is a member of.",
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"assignee_type": {
+							Description: "The type of the entity the role is assigned to, `user` or `group`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"scope_type": {
+							Description: "The scope in which the role is assigned, `CUSTOMER` or `ORG_UNIT`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"org_unit_id": {
+							Description: "If the role is restricted to an organization unit, the ID of the org unit.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"org_unit_path": {
+							Description: "If the role is restricted to an organization unit, the full path of the " +
+								"org unit.",
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"condition": {
+							Description: "The condition associated with the role assignment, if any.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"privileges": {
+				Description: "The privileges granted by the role assignments, including the child privileges of " +
+					"the privileges of the roles, sorted by service ID and privilege name.",
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_id": {
+							Description: "The obfuscated ID of the service this privilege is for.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"service_name": {
+							Description: "The name of the service this privilege is for.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"privilege_name": {
+							Description: "The name of the privilege.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"grants": {
+							Description: "The role assignments granting the privilege, sorted by role assignment ID.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"role_assignment_id": {
+										Description: "The ID of the role assignment.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"role_id": {
+										Description: "The ID of the role granting the privilege.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"role_name": {
+										Description: "The name of the role granting the privilege.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"scope_type": {
+										Description: "The scope in which the privilege is granted, `CUSTOMER` " +
+											"or `ORG_UNIT`.",
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"org_unit_id": {
+										Description: "If the privilege is restricted to an organization unit, " +
+											"the ID of the org unit.",
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"org_unit_path": {
+										Description: "If the privilege is restricted to an organization unit, " +
+											"the full path of the org unit.",
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"inherited": {
+										Description: "Whether the privilege is granted as the child of a privilege " +
+											"of the role, rather than being a privilege of the role itself.",
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceAdminEffectivePrivilegesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userKey := d.Get("user_key").(string)
+	log.Printf("[DEBUG] Getting Effective Privileges of %q", userKey)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	roleAssignmentsService, diags := GetRoleAssignmentsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	rolesService, diags := GetRolesService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	orgUnitsService, diags := GetOrgUnitsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	var assignments []*directory.RoleAssignment
+	err := roleAssignmentsService.List(client.Customer).UserKey(userKey).
+		IncludeIndirectRoleAssignments(d.Get("include_group_assignments").(bool)).
+		Pages(ctx, func(resp *directory.RoleAssignments) error {
+			assignments = append(assignments, resp.Items...)
+			return nil
+		})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sort.Slice(assignments, func(i, j int) bool {
+		return assignments[i].RoleAssignmentId < assignments[j].RoleAssignmentId
+	})
+
+	roles := map[int64]*directory.Role{}
+	orgUnitPaths := map[string]string{}
+	for _, ra := range assignments {
+		if _, ok := roles[ra.RoleId]; !ok {
+			role, err := rolesService.Get(client.Customer, strconv.FormatInt(ra.RoleId, 10)).Do()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			roles[ra.RoleId] = role
+		}
+
+		orgUnitId := strings.TrimPrefix(ra.OrgUnitId, "id:")
+		if _, ok := orgUnitPaths[orgUnitId]; ra.ScopeType == "ORG_UNIT" && !ok {
+			ou, err := orgUnitsService.Get(client.Customer, "id:"+orgUnitId).Do()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			orgUnitPaths[orgUnitId] = ou.OrgUnitPath
+		}
+	}
+
+	index, diags := getPrivilegeIndex(client)
+	if diags.HasError() {
+		return diags
+	}
+
+	isSuperAdmin := false
+	for _, role := range roles {
+		isSuperAdmin = isSuperAdmin || role.IsSuperAdminRole
+	}
+
+	d.SetId(userKey)
+	d.Set("is_super_admin", isSuperAdmin)
+
+	if err := d.Set("role_assignments", flattenAdminRoleAssignments(assignments, roles, orgUnitPaths)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("privileges", flattenEffectivePrivileges(assignments, roles, orgUnitPaths, index)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func flattenAdminRoleAssignments(assignments []*directory.RoleAssignment, roles map[int64]*directory.Role, orgUnitPaths map[string]string) []interface{} {
+	var result []interface{}
+	for _, ra := range assignments {
+		orgUnitId := strings.TrimPrefix(ra.OrgUnitId, "id:")
+
+		result = append(result, map[string]interface{}{
+			"role_assignment_id": strconv.FormatInt(ra.RoleAssignmentId, 10),
+			"role_id":            strconv.FormatInt(ra.RoleId, 10),
+			"role_name":          roles[ra.RoleId].RoleName,
+			"assigned_to":        ra.AssignedTo,
+			"assignee_type":      ra.AssigneeType,
+			"scope_type":         ra.ScopeType,
+			"org_unit_id":        orgUnitId,
+			"org_unit_path":      orgUnitPaths[orgUnitId],
+			"condition":          ra.Condition,
+		})
+	}
+
+	return result
+}
+
+// flattenEffectivePrivileges returns the privileges granted by the role assignments, each with the role
+// assignments granting it. A privilege granted both directly and as a child of another privilege of the
+// same role is not inherited.
+func flattenEffectivePrivileges(assignments []*directory.RoleAssignment, roles map[int64]*directory.Role, orgUnitPaths map[string]string, index *privilegeIndex) []interface{} {
+	type effectivePrivilege struct {
+		serviceId     string
+		privilegeName string
+		grants        map[int64]map[string]interface{}
+	}
+	privileges := map[string]*effectivePrivilege{}
+
+	grant := func(ra *directory.RoleAssignment, privilegeName, serviceId string, inherited bool) {
+		key := privilegeKey(privilegeName, serviceId)
+		if privileges[key] == nil {
+			privileges[key] = &effectivePrivilege{
+				serviceId:     serviceId,
+				privilegeName: privilegeName,
+				grants:        map[int64]map[string]interface{}{},
+			}
+		}
+
+		if g, ok := privileges[key].grants[ra.RoleAssignmentId]; ok {
+			g["inherited"] = g["inherited"].(bool) && inherited
+			return
+		}
+
+		orgUnitId := strings.TrimPrefix(ra.OrgUnitId, "id:")
+		privileges[key].grants[ra.RoleAssignmentId] = map[string]interface{}{
+			"role_assignment_id": strconv.FormatInt(ra.RoleAssignmentId, 10),
+			"role_id":            strconv.FormatInt(ra.RoleId, 10),
+			"role_name":          roles[ra.RoleId].RoleName,
+			"scope_type":         ra.ScopeType,
+			"org_unit_id":        orgUnitId,
+			"org_unit_path":      orgUnitPaths[orgUnitId],
+			"inherited":          inherited,
+		}
+	}
+
+	for _, ra := range assignments {
+		for _, priv := range roles[ra.RoleId].RolePrivileges {
+			grant(ra, priv.PrivilegeName, priv.ServiceId, false)
+
+			for _, d := range index.descendants[privilegeKey(priv.PrivilegeName, priv.ServiceId)] {
+				child := d.(map[string]interface{})
+				grant(ra, child["privilege_name"].(string), child["service_id"].(string), true)
+			}
+		}
+	}
+
+	var sorted []*effectivePrivilege
+	for _, p := range privileges {
+		sorted = append(sorted, p)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].serviceId != sorted[j].serviceId {
+			return sorted[i].serviceId < sorted[j].serviceId
+		}
+		return sorted[i].privilegeName < sorted[j].privilegeName
+	})
+
+	var result []interface{}
+	for _, p := range sorted {
+		var ids []int64
+		for id := range p.grants {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+		var grants []interface{}
+		for _, id := range ids {
+			grants = append(grants, p.grants[id])
+		}
+
+		result = append(result, map[string]interface{}{
+			"service_id":     p.serviceId,
+			"service_name":   index.serviceNames[p.serviceId],
+			"privilege_name": p.privilegeName,
+			"grants":         grants,
+		})
+	}
+
+	return result
+}
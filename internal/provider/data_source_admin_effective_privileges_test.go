@@ -0,0 +1,138 @@
+package googleworkspace
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func TestFlattenEffectivePrivileges(t *testing.T) {
+	t.Parallel()
+
+	roles := map[int64]*directory.Role{
+		1: {
+			RoleName: "parent",
+			RolePrivileges: []*directory.RoleRolePrivileges{
+				{PrivilegeName: "A", ServiceId: "1"},
+				{PrivilegeName: "AB", ServiceId: "1"},
+			},
+		},
+		2: {
+			RoleName: "child",
+			RolePrivileges: []*directory.RoleRolePrivileges{
+				{PrivilegeName: "AA", ServiceId: "1"},
+				{PrivilegeName: "B", ServiceId: "2"},
+			},
+		},
+	}
+	assignments := []*directory.RoleAssignment{
+		{RoleAssignmentId: 10, RoleId: 1, ScopeType: "CUSTOMER"},
+		{RoleAssignmentId: 20, RoleId: 2, ScopeType: "ORG_UNIT", OrgUnitId: "id:ou1"},
+	}
+
+	actual := flattenEffectivePrivileges(assignments, roles, map[string]string{"ou1": "/sales"}, testPrivilegeIndex())
+
+	grant := func(id, roleId, roleName string, inherited bool) map[string]interface{} {
+		g := map[string]interface{}{
+			"role_assignment_id": id,
+			"role_id":            roleId,
+			"role_name":          roleName,
+			"scope_type":         "CUSTOMER",
+			"org_unit_id":        "",
+			"org_unit_path":      "",
+			"inherited":          inherited,
+		}
+		if roleId == "2" {
+			g["scope_type"] = "ORG_UNIT"
+			g["org_unit_id"] = "ou1"
+			g["org_unit_path"] = "/sales"
+		}
+		return g
+	}
+	privilege := func(name, serviceId string, grants ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"service_id":     serviceId,
+			"service_name":   "",
+			"privilege_name": name,
+			"grants":         grants,
+		}
+	}
+
+	expected := []interface{}{
+		privilege("A", "1", grant("10", "1", "parent", false)),
+		privilege("AA", "1", grant("10", "1", "parent", true), grant("20", "2", "child", false)),
+		privilege("AAA", "1", grant("10", "1", "parent", true), grant("20", "2", "child", true)),
+		privilege("AB", "1", grant("10", "1", "parent", false)),
+		privilege("B", "2", grant("20", "2", "child", false)),
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("effective privileges not equal\n\nactual %+v\n\nexpected %+v", actual, expected)
+	}
+}
+
+func TestAccDataSourceAdminEffectivePrivileges_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	data := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"password":   acctest.RandString(10),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAdminEffectivePrivileges_basic(data),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.googleworkspace_admin_effective_privileges.test", "is_super_admin", "false"),
+					resource.TestCheckResourceAttr("data.googleworkspace_admin_effective_privileges.test", "role_assignments.#", "1"),
+					resource.TestCheckResourceAttrPair("data.googleworkspace_admin_effective_privileges.test", "role_assignments.0.role_id",
+						"data.googleworkspace_role.test", "id"),
+					resource.TestCheckResourceAttrSet("data.googleworkspace_admin_effective_privileges.test", "privileges.0.grants.0.role_assignment_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAdminEffectivePrivileges_basic(data map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_user" "test" {
+  primary_email = "%{userEmail}@%{domainName}"
+  password      = "%{password}"
+
+  name {
+    family_name = "Scott"
+    given_name  = "Michael"
+  }
+}
+
+data "googleworkspace_role" "test" {
+  name = "_GROUPS_ADMIN_ROLE"
+}
+
+resource "googleworkspace_role_assignment" "test" {
+  role_id     = data.googleworkspace_role.test.id
+  assigned_to = googleworkspace_user.test.id
+}
+
+data "googleworkspace_admin_effective_privileges" "test" {
+  user_key = googleworkspace_role_assignment.test.assigned_to
+}
+`, data)
+}
@@ -0,0 +1,403 @@
+package googleworkspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// googleMxRecords are the MX records recommended for Gmail, by priority.
+var googleMxRecords = map[string]int{
+	"smtp.google.com": 1,
+}
+
+// googleLegacyMxRecords are the MX records recommended for Gmail before smtp.google.com.
+var googleLegacyMxRecords = map[string]int{
+	"aspmx.l.google.com":      1,
+	"alt1.aspmx.l.google.com": 5,
+	"alt2.aspmx.l.google.com": 5,
+	"alt3.aspmx.l.google.com": 10,
+	"alt4.aspmx.l.google.com": 10,
+}
+
+const googleSpfInclude = "include:_spf.google.com"
+
+func dataSourceDomainDnsRecords() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Domain DNS Records data source returns the MX, SPF, DKIM and DMARC records recommended for " +
+			"Gmail on a domain, to be created with a DNS provider. The DKIM public key is generated in the Admin " +
+			"console and not exposed by an API, so the DKIM record is only returned if the key is given. If " +
+			"`check_records` is set, the records are looked up and the ones that are missing or wrong are reported.",
+
+		ReadContext: dataSourceDomainDnsRecordsRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain_name": {
+				Description: "The domain name the records are for.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"legacy_mx": {
+				Description: "If `true`, the five `aspmx.l.google.com` MX records are returned instead of " +
+					"`smtp.google.com`.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"dkim_selector": {
+				Description: "The prefix selector of the DKIM key.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "google",
+			},
+			"dkim_public_key": {
+				Description: "The DKIM public key, as shown in the Admin console under Apps > Google Workspace > " +
+					"Gmail > Authenticate email. Either the full record value, `v=DKIM1; k=rsa; p=...`, or only the key.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"dmarc_policy": {
+				Description: "The DMARC policy for messages failing authentication, `none`, `quarantine` or `reject`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "none",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice([]string{"none", "quarantine", "reject"}, false),
+				),
+			},
+			"dmarc_report_email": {
+				Description: "The email address aggregate DMARC reports are sent to.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"check_records": {
+				Description: "If `true`, the records are looked up, and `status` and `found_values` are set.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"resolver": {
+				Description: "The address of the DNS resolver the records are looked up with, e.g. `8.8.8.8:53` " +
+					"or `127.0.0.1:5353`. The resolver of the system if unset.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"records": {
+				Description: "The recommended records.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"purpose": {
+							Description: "What the record is for, `MX`, `SPF`, `DKIM` or `DMARC`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "The type of the record, `MX` or `TXT`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The fully qualified name of the record.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"value": {
+							Description: "The value of the record. The mail server for MX records.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"priority": {
+							Description: "The priority of MX records.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"status": {
+							Description: "If `check_records` is `true`, whether the record is `ok`, `missing` or " +
+								"`wrong`.",
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"found_values": {
+							Description: "If `check_records` is `true`, the values found for the record, e.g. " +
+								"`10 mx.example.com` for MX records.",
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"all_records_ok": {
+				Description: "If `check_records` is `true`, whether all records are `ok`.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// dnsRecord is a record recommended for Gmail.
+type dnsRecord struct {
+	Purpose  string
+	Type     string
+	Name     string
+	Value    string
+	Priority int
+
+	Status      string
+	FoundValues []string
+}
+
+// dnsLookup is the part of net.Resolver used to check the records.
+type dnsLookup interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+func dataSourceDomainDnsRecordsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	domainName := strings.ToLower(strings.TrimSuffix(d.Get("domain_name").(string), "."))
+
+	records := expandDomainDnsRecords(domainName, d.Get("legacy_mx").(bool), d.Get("dkim_selector").(string),
+		d.Get("dkim_public_key").(string), d.Get("dmarc_policy").(string), d.Get("dmarc_report_email").(string))
+
+	allOk := false
+	if d.Get("check_records").(bool) {
+		log.Printf("[DEBUG] Checking DNS Records of Domain %q", domainName)
+
+		var err error
+		allOk, err = checkDomainDnsRecords(ctx, newDnsResolver(d.Get("resolver").(string)), records)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(domainName)
+	d.Set("all_records_ok", allOk)
+
+	if err := d.Set("records", flattenDomainDnsRecords(records)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// newDnsResolver returns the resolver of the system, or one querying address if set.
+func newDnsResolver(address string) *net.Resolver {
+	if address == "" {
+		return net.DefaultResolver
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			dialer := net.Dialer{Timeout: 10 * time.Second}
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+}
+
+func expandDomainDnsRecords(domainName string, legacyMx bool, dkimSelector, dkimPublicKey, dmarcPolicy, dmarcReportEmail string) []*dnsRecord {
+	var records []*dnsRecord
+
+	mx := googleMxRecords
+	if legacyMx {
+		mx = googleLegacyMxRecords
+	}
+	var hosts []string
+	for host := range mx {
+		hosts = append(hosts, host)
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		if mx[hosts[i]] != mx[hosts[j]] {
+			return mx[hosts[i]] < mx[hosts[j]]
+		}
+		return hosts[i] < hosts[j]
+	})
+	for _, host := range hosts {
+		records = append(records, &dnsRecord{Purpose: "MX", Type: "MX", Name: domainName, Value: host, Priority: mx[host]})
+	}
+
+	records = append(records, &dnsRecord{
+		Purpose: "SPF",
+		Type:    "TXT",
+		Name:    domainName,
+		Value:   "v=spf1 " + googleSpfInclude + " ~all",
+	})
+
+	if dkimPublicKey != "" {
+		records = append(records, &dnsRecord{
+			Purpose: "DKIM",
+			Type:    "TXT",
+			Name:    fmt.Sprintf("%s._domainkey.%s", dkimSelector, domainName),
+			Value:   "v=DKIM1; k=rsa; p=" + dkimKey(dkimPublicKey),
+		})
+	}
+
+	dmarc := "v=DMARC1; p=" + dmarcPolicy
+	if dmarcReportEmail != "" {
+		dmarc += "; rua=mailto:" + dmarcReportEmail
+	}
+	records = append(records, &dnsRecord{Purpose: "DMARC", Type: "TXT", Name: "_dmarc." + domainName, Value: dmarc})
+
+	return records
+}
+
+// dkimKey returns the public key of a DKIM record value, or the value itself if it's only the key.
+func dkimKey(value string) string {
+	value = strings.Join(strings.Fields(value), "")
+	for _, tag := range strings.Split(value, ";") {
+		if strings.HasPrefix(tag, "p=") {
+			return strings.TrimPrefix(tag, "p=")
+		}
+	}
+	return value
+}
+
+// dnsTags returns the tags of a DMARC or DKIM record value.
+func dnsTags(value string) map[string]string {
+	tags := map[string]string{}
+	for _, tag := range strings.Split(value, ";") {
+		k, v, _ := strings.Cut(strings.TrimSpace(tag), "=")
+		tags[strings.ToLower(strings.TrimSpace(k))] = strings.TrimSpace(v)
+	}
+	return tags
+}
+
+// checkDomainDnsRecords looks up the records, and sets their status and found values. It returns
+// whether all records are ok.
+func checkDomainDnsRecords(ctx context.Context, lookup dnsLookup, records []*dnsRecord) (bool, error) {
+	mx := map[string][]*net.MX{}
+	txt := map[string][]string{}
+
+	allOk := true
+	for _, r := range records {
+		var err error
+		switch r.Type {
+		case "MX":
+			if _, ok := mx[r.Name]; !ok {
+				mx[r.Name], err = lookup.LookupMX(ctx, r.Name)
+			}
+		case "TXT":
+			if _, ok := txt[r.Name]; !ok {
+				txt[r.Name], err = lookup.LookupTXT(ctx, r.Name)
+			}
+		}
+
+		var dnsErr *net.DNSError
+		if err != nil && !(errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+			return false, fmt.Errorf("looking up the %s records of %s: %w", r.Type, r.Name, err)
+		}
+
+		switch r.Purpose {
+		case "MX":
+			r.Status = "missing"
+			for _, found := range mx[r.Name] {
+				host := strings.ToLower(strings.TrimSuffix(found.Host, "."))
+				r.FoundValues = append(r.FoundValues, fmt.Sprintf("%d %s", found.Pref, host))
+				if host == r.Value {
+					if int(found.Pref) == r.Priority {
+						r.Status = "ok"
+					} else if r.Status != "ok" {
+						r.Status = "wrong"
+					}
+				}
+			}
+		case "SPF":
+			r.FoundValues = txtRecordsWithPrefix(txt[r.Name], "v=spf1")
+			r.Status = "missing"
+			// more than one SPF record is an error for receivers
+			if len(r.FoundValues) > 1 {
+				r.Status = "wrong"
+			} else if len(r.FoundValues) == 1 {
+				r.Status = "wrong"
+				for _, term := range strings.Fields(strings.ToLower(r.FoundValues[0])) {
+					if term == googleSpfInclude || term == "+"+googleSpfInclude {
+						r.Status = "ok"
+					}
+				}
+			}
+		case "DKIM":
+			r.FoundValues = txtRecordsWithPrefix(txt[r.Name], "v=DKIM1")
+			r.Status = "missing"
+			for _, found := range r.FoundValues {
+				r.Status = "wrong"
+				if dkimKey(found) == dkimKey(r.Value) {
+					r.Status = "ok"
+					break
+				}
+			}
+		case "DMARC":
+			r.FoundValues = txtRecordsWithPrefix(txt[r.Name], "v=DMARC1")
+			r.Status = "missing"
+			if len(r.FoundValues) > 1 {
+				r.Status = "wrong"
+			} else if len(r.FoundValues) == 1 {
+				r.Status = "ok"
+				found, want := dnsTags(r.FoundValues[0]), dnsTags(r.Value)
+				for k, v := range want {
+					// reports may be sent to more than one address
+					if k == "rua" && strings.Contains(strings.ToLower(found[k]), strings.ToLower(v)) {
+						continue
+					}
+					if !strings.EqualFold(found[k], v) {
+						r.Status = "wrong"
+					}
+				}
+			}
+		}
+
+		allOk = allOk && r.Status == "ok"
+	}
+
+	return allOk, nil
+}
+
+// txtRecordsWithPrefix returns the TXT records starting with the version tag, ignoring case.
+func txtRecordsWithPrefix(values []string, prefix string) []string {
+	var result []string
+	for _, v := range values {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(v)), strings.ToLower(prefix)) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func flattenDomainDnsRecords(records []*dnsRecord) []interface{} {
+	var result []interface{}
+	for _, r := range records {
+		result = append(result, map[string]interface{}{
+			"purpose":      r.Purpose,
+			"type":         r.Type,
+			"name":         r.Name,
+			"value":        r.Value,
+			"priority":     r.Priority,
+			"status":       r.Status,
+			"found_values": r.FoundValues,
+		})
+	}
+	return result
+}
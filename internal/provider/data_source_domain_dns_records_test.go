@@ -0,0 +1,212 @@
+package googleworkspace
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// fakeDnsLookup serves MX and TXT records by name, and not found errors otherwise.
+type fakeDnsLookup struct {
+	mx  map[string][]*net.MX
+	txt map[string][]string
+}
+
+func (f fakeDnsLookup) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	if mx, ok := f.mx[name]; ok {
+		return mx, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (f fakeDnsLookup) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if txt, ok := f.txt[name]; ok {
+		return txt, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func TestExpandDomainDnsRecords(t *testing.T) {
+	t.Parallel()
+
+	records := expandDomainDnsRecords("example.com", true, "google", "v=DKIM1; k=rsa;\n p=MIIB AQAB", "reject", "dmarc@example.com")
+
+	var actual []string
+	for _, r := range records {
+		actual = append(actual, strings.Join([]string{r.Purpose, r.Name, r.Value}, " "))
+	}
+
+	expected := []string{
+		"MX example.com aspmx.l.google.com",
+		"MX example.com alt1.aspmx.l.google.com",
+		"MX example.com alt2.aspmx.l.google.com",
+		"MX example.com alt3.aspmx.l.google.com",
+		"MX example.com alt4.aspmx.l.google.com",
+		"SPF example.com v=spf1 include:_spf.google.com ~all",
+		"DKIM google._domainkey.example.com v=DKIM1; k=rsa; p=MIIBAQAB",
+		"DMARC _dmarc.example.com v=DMARC1; p=reject; rua=mailto:dmarc@example.com",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("records not equal\n\nactual %v\n\nexpected %v", actual, expected)
+	}
+
+	// the DKIM record is only returned with a key
+	for _, r := range expandDomainDnsRecords("example.com", false, "google", "", "none", "") {
+		if r.Purpose == "DKIM" {
+			t.Errorf("unexpected DKIM record %+v", r)
+		}
+	}
+}
+
+func TestCheckDomainDnsRecords(t *testing.T) {
+	t.Parallel()
+
+	lookup := fakeDnsLookup{
+		mx: map[string][]*net.MX{
+			"example.com": {
+				{Host: "ASPMX.L.GOOGLE.COM.", Pref: 1},
+				{Host: "alt1.aspmx.l.google.com.", Pref: 10},
+				{Host: "mx.example.com.", Pref: 20},
+			},
+		},
+		txt: map[string][]string{
+			"example.com": {
+				"google-site-verification=abc",
+				"v=spf1 include:_spf.google.com ~all",
+			},
+			"google._domainkey.example.com": {"v=DKIM1; k=rsa; p=OTHER"},
+			"_dmarc.example.com":            {"v=DMARC1; p=none; rua=mailto:a@example.com,mailto:dmarc@example.com"},
+		},
+	}
+
+	records := expandDomainDnsRecords("example.com", true, "google", "MIIBAQAB", "none", "dmarc@example.com")
+
+	allOk, err := checkDomainDnsRecords(context.Background(), lookup, records)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if allOk {
+		t.Errorf("expected records not to be ok")
+	}
+
+	var actual []string
+	for _, r := range records {
+		actual = append(actual, r.Value+" "+r.Status)
+	}
+
+	expected := []string{
+		"aspmx.l.google.com ok",
+		"alt1.aspmx.l.google.com wrong",
+		"alt2.aspmx.l.google.com missing",
+		"alt3.aspmx.l.google.com missing",
+		"alt4.aspmx.l.google.com missing",
+		"v=spf1 include:_spf.google.com ~all ok",
+		"v=DKIM1; k=rsa; p=MIIBAQAB wrong",
+		"v=DMARC1; p=none; rua=mailto:dmarc@example.com ok",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("statuses not equal\n\nactual %v\n\nexpected %v", actual, expected)
+	}
+
+	if want := []string{"1 aspmx.l.google.com", "10 alt1.aspmx.l.google.com", "20 mx.example.com"}; !reflect.DeepEqual(records[0].FoundValues, want) {
+		t.Errorf("expected found values %v, got %v", want, records[0].FoundValues)
+	}
+	if want := []string{"v=spf1 include:_spf.google.com ~all"}; !reflect.DeepEqual(records[5].FoundValues, want) {
+		t.Errorf("expected found values %v, got %v", want, records[5].FoundValues)
+	}
+}
+
+func TestCheckDomainDnsRecords_spf(t *testing.T) {
+	t.Parallel()
+
+	for txt, want := range map[string]string{
+		"v=spf1 include:_spf.google.com -all":                "ok",
+		"v=spf1 +include:_spf.google.com include:x.com -all": "ok",
+		"v=spf1 include:_spf.google.com.evil.com ~all":       "wrong",
+		"v=spf1 mx ~all": "wrong",
+		"other":          "missing",
+	} {
+		records := expandDomainDnsRecords("example.com", false, "google", "", "none", "")
+		lookup := fakeDnsLookup{txt: map[string][]string{"example.com": {txt}}}
+
+		if _, err := checkDomainDnsRecords(context.Background(), lookup, records); err != nil {
+			t.Fatalf("%s: unexpected error: %v", txt, err)
+		}
+		if records[1].Status != want {
+			t.Errorf("%s: expected %s, got %s", txt, want, records[1].Status)
+		}
+	}
+}
+
+// TestNewDnsResolver checks the records against a DNS server standing in for the resolver.
+func TestNewDnsResolver(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("could not listen for DNS queries: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			var query dnsmessage.Message
+			if err := query.Unpack(buf[:n]); err != nil || len(query.Questions) == 0 {
+				continue
+			}
+			q := query.Questions[0]
+
+			resp := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: query.ID, Response: true, Authoritative: true},
+				Questions: query.Questions,
+			}
+			hdr := dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: dnsmessage.ClassINET, TTL: 300}
+			switch {
+			case q.Type == dnsmessage.TypeMX && q.Name.String() == "example.com.":
+				resp.Answers = append(resp.Answers, dnsmessage.Resource{
+					Header: hdr,
+					Body:   &dnsmessage.MXResource{Pref: 1, MX: dnsmessage.MustNewName("smtp.google.com.")},
+				})
+			case q.Type == dnsmessage.TypeTXT && q.Name.String() == "example.com.":
+				resp.Answers = append(resp.Answers, dnsmessage.Resource{
+					Header: hdr,
+					Body:   &dnsmessage.TXTResource{TXT: []string{"v=spf1 include:_spf.google.com ~all"}},
+				})
+			default:
+				resp.Header.RCode = dnsmessage.RCodeNameError
+			}
+
+			packed, err := resp.Pack()
+			if err != nil {
+				continue
+			}
+			conn.WriteTo(packed, addr)
+		}
+	}()
+
+	records := expandDomainDnsRecords("example.com", false, "google", "", "none", "")
+
+	allOk, err := checkDomainDnsRecords(context.Background(), newDnsResolver(conn.LocalAddr().String()), records)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if allOk {
+		t.Errorf("expected the DMARC record to be missing")
+	}
+
+	var actual []string
+	for _, r := range records {
+		actual = append(actual, r.Purpose+" "+r.Status)
+	}
+	if want := []string{"MX ok", "SPF ok", "DMARC missing"}; !reflect.DeepEqual(actual, want) {
+		t.Errorf("expected %v, got %v", want, actual)
+	}
+}
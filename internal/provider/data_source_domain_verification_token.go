@@ -0,0 +1,148 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"google.golang.org/api/siteverification/v1"
+)
+
+// domainVerificationMethods are the Site Verification methods available for domains.
+var domainVerificationMethods = []string{"DNS_TXT", "DNS_CNAME"}
+
+func dataSourceDomainVerificationToken() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Domain Verification Token data source returns the DNS record proving the ownership of a " +
+			"domain, to be created with a DNS provider before the domain is verified with a " +
+			"`googleworkspace_domain_verification` resource. Domain Verification Token resides under the " +
+			"`https://www.googleapis.com/auth/siteverification` client scope.",
+
+		ReadContext: dataSourceDomainVerificationTokenRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain_name": {
+				Description: "The domain name to verify.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"verification_method": {
+				Description: "The verification method, `DNS_TXT` or `DNS_CNAME`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "DNS_TXT",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice(domainVerificationMethods, false),
+				),
+			},
+			"token": {
+				Description: "The verification token, as returned by the Site Verification API.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"record_type": {
+				Description: "The type of the DNS record, `TXT` or `CNAME`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"record_name": {
+				Description: "The fully qualified name of the DNS record.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"record_value": {
+				Description: "The value of the DNS record.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceDomainVerificationTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	domainName := d.Get("domain_name").(string)
+	method := d.Get("verification_method").(string)
+
+	siteVerificationService, diags := client.NewSiteVerificationService()
+	if diags.HasError() {
+		return diags
+	}
+
+	webResourceService, diags := GetWebResourceService(siteVerificationService)
+	if diags.HasError() {
+		return diags
+	}
+
+	token, err := getDomainVerificationToken(webResourceService, domainName, method)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	recordType, recordName, recordValue, err := domainVerificationRecord(domainName, method, token)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", domainName, method))
+	d.Set("token", token)
+	d.Set("record_type", recordType)
+	d.Set("record_name", recordName)
+	d.Set("record_value", recordValue)
+
+	return diags
+}
+
+// getDomainVerificationToken returns the token of the verification method for the domain.
+func getDomainVerificationToken(webResourceService *siteverification.WebResourceService, domainName, method string) (string, error) {
+	log.Printf("[DEBUG] Getting %s Verification Token for Domain %q", method, domainName)
+
+	resp, err := webResourceService.GetToken(&siteverification.SiteVerificationWebResourceGettokenRequest{
+		Site: &siteverification.SiteVerificationWebResourceGettokenRequestSite{
+			Identifier: domainName,
+			Type:       "INET_DOMAIN",
+		},
+		VerificationMethod: method,
+	}).Do()
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Token, nil
+}
+
+// domainVerificationRecord returns the DNS record of a verification token. CNAME tokens are the
+// label of the record and its target, separated by a space.
+func domainVerificationRecord(domainName, method, token string) (recordType, recordName, recordValue string, err error) {
+	domainName = strings.TrimSuffix(domainName, ".")
+
+	switch method {
+	case "DNS_TXT":
+		return "TXT", domainName, token, nil
+	case "DNS_CNAME":
+		parts := strings.Fields(token)
+		if len(parts) != 2 {
+			return "", "", "", fmt.Errorf("unexpected CNAME verification token %q", token)
+		}
+		return "CNAME", parts[0] + "." + domainName, parts[1], nil
+	}
+
+	return "", "", "", fmt.Errorf("unsupported verification method %q", method)
+}
@@ -0,0 +1,65 @@
+package googleworkspace
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDomainVerificationToken_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "googleworkspace_domain_verification_token" "test" {
+  domain_name = "%s"
+}
+`, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.googleworkspace_domain_verification_token.test", "record_type", "TXT"),
+					resource.TestCheckResourceAttr("data.googleworkspace_domain_verification_token.test", "record_name", domainName),
+					resource.TestCheckResourceAttrSet("data.googleworkspace_domain_verification_token.test", "token"),
+				),
+			},
+		},
+	})
+}
+
+func TestDomainVerificationRecord(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		method, token                       string
+		recordType, recordName, recordValue string
+	}{
+		{"DNS_TXT", "google-site-verification=abc", "TXT", "example.com", "google-site-verification=abc"},
+		{"DNS_CNAME", "label123 gv-abc.dv.googlehosted.com", "CNAME", "label123.example.com", "gv-abc.dv.googlehosted.com"},
+	}
+
+	for _, c := range cases {
+		recordType, recordName, recordValue, err := domainVerificationRecord("example.com.", c.method, c.token)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.method, err)
+		}
+		if recordType != c.recordType || recordName != c.recordName || recordValue != c.recordValue {
+			t.Errorf("%s: expected %s %s %s, got %s %s %s", c.method, c.recordType, c.recordName, c.recordValue,
+				recordType, recordName, recordValue)
+		}
+	}
+
+	if _, _, _, err := domainVerificationRecord("example.com", "DNS_CNAME", "invalid"); err == nil {
+		t.Errorf("expected an error for an invalid CNAME token")
+	}
+}
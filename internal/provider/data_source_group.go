@@ -10,6 +10,7 @@ import (
 func dataSourceGroup() *schema.Resource {
 	// Generate datasource schema from resource
 	dsSchema := datasourceSchemaFromResourceSchema(resourceGroup().Schema)
+	delete(dsSchema, "manage_aliases")
 	addExactlyOneOfFieldsToSchema(dsSchema, "id", "email")
 
 	return &schema.Resource{
@@ -59,5 +60,5 @@ func dataSourceGroupRead(ctx context.Context, d *schema.ResourceData, meta inter
 		d.SetId(group.Id)
 	}
 
-	return resourceGroupRead(ctx, d, meta)
+	return readGroup(ctx, d, meta, false)
 }
@@ -2,15 +2,27 @@ package googleworkspace
 
 import (
 	"context"
+	"fmt"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	directory "google.golang.org/api/admin/directory/v1"
 )
 
 func dataSourceGroups() *schema.Resource {
 	// Generate datasource schema from resource
 	dsGroupSchema := datasourceSchemaFromResourceSchema(resourceGroup().Schema)
+	delete(dsGroupSchema, "manage_aliases")
+	dsGroupSchema["settings"] = &schema.Schema{
+		Description: "The settings of the group. Only populated when `include_settings` is `true`.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: datasourceSchemaFromResourceSchema(resourceGroupSettings().Schema),
+		},
+	}
 
 	return &schema.Resource{
 		// This description is used by the documentation generator and the language server.
@@ -20,6 +32,41 @@ func dataSourceGroups() *schema.Resource {
 		ReadContext: dataSourceGroupsRead,
 
 		Schema: map[string]*schema.Schema{
+			"query": {
+				Description: "Query string search, e.g. `email:sales*`. Complete documentation is at " +
+					"https://developers.google.com/admin-sdk/directory/v1/guides/search-groups",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"user_key"},
+			},
+			"domain": {
+				Description: "The domain name. Use this field to get groups from only one domain. " +
+					"To return all domains for a customer account, leave this field unset.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"user_key": {
+				Description: "Email or immutable ID of a user or group. If set, only the groups the given " +
+					"user or group is a member of are returned.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"query"},
+			},
+			"include_settings": {
+				Description: "If true, the settings of each group are retrieved from the Groups Settings API " +
+					"and returned in `settings`. This requires the " +
+					"`https://www.googleapis.com/auth/apps.groups.settings` client scope.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"settings_concurrency": {
+				Description:      "The maximum number of group settings requests made in parallel when `include_settings` is `true`.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          10,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 50)),
+			},
 			"groups": {
 				Description: "A list of Group resources.",
 				Type:        schema.TypeList,
@@ -46,8 +93,28 @@ func dataSourceGroupsRead(ctx context.Context, d *schema.ResourceData, meta inte
 		return diags
 	}
 
+	listCall := groupsService.List()
+
+	// customer cannot be combined with userKey, and domain takes precedence over customer
+	domain := d.Get("domain").(string)
+	userKey := d.Get("user_key").(string)
+	switch {
+	case domain != "":
+		listCall = listCall.Domain(domain)
+	case userKey == "":
+		listCall = listCall.Customer(client.Customer)
+	}
+
+	if userKey != "" {
+		listCall = listCall.UserKey(userKey)
+	}
+
+	if query := d.Get("query").(string); query != "" {
+		listCall = listCall.Query(query)
+	}
+
 	var result []*directory.Group
-	err := groupsService.List().Customer(client.Customer).Pages(ctx, func(resp *directory.Groups) error {
+	err := listCall.Pages(ctx, func(resp *directory.Groups) error {
 		result = append(result, resp.Groups...)
 
 		return nil
@@ -57,7 +124,16 @@ func dataSourceGroupsRead(ctx context.Context, d *schema.ResourceData, meta inte
 		return handleNotFoundError(err, d, "groups")
 	}
 
-	if err := d.Set("groups", flattenGroups(result)); err != nil {
+	groups := flattenGroups(result)
+
+	if d.Get("include_settings").(bool) {
+		diags = addGroupsSettings(ctx, client, groups, d.Get("settings_concurrency").(int))
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if err := d.Set("groups", groups); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -66,7 +142,48 @@ func dataSourceGroupsRead(ctx context.Context, d *schema.ResourceData, meta inte
 	return diags
 }
 
-func flattenGroups(groups []*directory.Group) interface{} {
+// addGroupsSettings retrieves the settings of each flattened group, making at most
+// concurrency requests at a time, and stores them under the group's `settings` key.
+func addGroupsSettings(ctx context.Context, client *apiClient, groups []interface{}, concurrency int) diag.Diagnostics {
+	groupsSettingsService, diags := client.NewGroupsSettingsService()
+	if diags.HasError() {
+		return diags
+	}
+
+	settingsService, diags := GetGroupsSettingsService(groupsSettingsService)
+	if diags.HasError() {
+		return diags
+	}
+
+	err := forEachConcurrently(ctx, len(groups), concurrency, func(ctx context.Context, i int) error {
+		group := groups[i].(map[string]interface{})
+		email := group["email"].(string)
+
+		log.Printf("[DEBUG] Getting Group Settings %q", email)
+		settings, err := settingsService.Get(email).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("error retrieving settings for group %s: %w", email, err)
+		}
+
+		flattened, err := flattenGroupSettings(settings)
+		if err != nil {
+			return fmt.Errorf("error flattening settings for group %s: %w", email, err)
+		}
+		flattened["id"] = email
+
+		// each group is only written by a single call, so no locking is required
+		group["settings"] = []interface{}{flattened}
+
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func flattenGroups(groups []*directory.Group) []interface{} {
 	var result []interface{}
 
 	for _, group := range groups {
@@ -49,3 +49,49 @@ data "googleworkspace_groups" "groups" {
 }
 `
 }
+
+func TestAccDataSourceGroups_query(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testGroupVals := map[string]interface{}{
+		"domainName": domainName,
+		"email":      fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGroups_query(testGroupVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.googleworkspace_groups.groups",
+						"groups.#", "1"),
+					resource.TestCheckResourceAttrPair("data.googleworkspace_groups.groups", "groups.0.email",
+						"googleworkspace_group.my-group", "email"),
+					resource.TestCheckResourceAttr("data.googleworkspace_groups.groups",
+						"groups.0.settings.#", "1"),
+					resource.TestCheckResourceAttrSet("data.googleworkspace_groups.groups",
+						"groups.0.settings.0.who_can_join"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGroups_query(testGroupVals map[string]interface{}) string {
+	return testAccResourceGroup_full(testGroupVals) + `
+
+data "googleworkspace_groups" "groups" {
+  domain           = split("@", googleworkspace_group.my-group.email)[1]
+  query            = "email:${googleworkspace_group.my-group.email}"
+  include_settings = true
+}
+`
+}
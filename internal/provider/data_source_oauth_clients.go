@@ -0,0 +1,242 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataSourceOAuthClients() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "OAuth Clients data source lists the third-party applications the users of the customer " +
+			"have issued OAuth tokens to, aggregated by client ID. The tokens of each user are retrieved in " +
+			"parallel. OAuth Clients resides under the `https://www.googleapis.com/auth/admin.directory.user.readonly` " +
+			"and `https://www.googleapis.com/auth/admin.directory.user.security` client scopes.",
+
+		ReadContext: dataSourceOAuthClientsRead,
+
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Description: "Query string to filter the users whose tokens are listed, e.g. `orgUnitPath='/Sales'`. " +
+					"Complete documentation is at https://developers.google.com/admin-sdk/directory/v1/guides/search-users",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"domain": {
+				Description: "The domain name. Use this field to only list the tokens of the users of one domain. " +
+					"To return all domains for a customer account, leave this field unset.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"concurrency": {
+				Description:      "The maximum number of token requests made in parallel.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          10,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 50)),
+			},
+			"users_scanned": {
+				Description: "The number of users whose tokens were listed.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"clients": {
+				Description: "The applications users have issued tokens to, sorted by client ID.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"client_id": {
+							Description: "The Client ID of the application.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"display_text": {
+							Description: "The displayable name of the application.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"anonymous": {
+							Description: "Whether the application is registered with Google.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"native_app": {
+							Description: "Whether the tokens are issued to an installed application.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"scopes": {
+							Description: "The authorization scopes granted to the application by any user, sorted.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"user_count": {
+							Description: "The number of users that have issued a token to the application.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"users": {
+							Description: "The primary emails of the users that have issued a token to the application, sorted.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOAuthClientsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	log.Printf("[DEBUG] Getting OAuth Clients")
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	tokensService, diags := GetTokensService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	listCall := usersService.List().Fields("nextPageToken", "users(primaryEmail)")
+
+	// domain takes precedence over customer
+	if domain := d.Get("domain").(string); domain != "" {
+		listCall = listCall.Domain(domain)
+	} else {
+		listCall = listCall.Customer(client.Customer)
+	}
+
+	if query := d.Get("query").(string); query != "" {
+		listCall = listCall.Query(query)
+	}
+
+	var emails []string
+	err := listCall.Pages(ctx, func(resp *directory.Users) error {
+		for _, user := range resp.Users {
+			emails = append(emails, user.PrimaryEmail)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tokens := make([][]*directory.Token, len(emails))
+	err = forEachConcurrently(ctx, len(emails), d.Get("concurrency").(int), func(ctx context.Context, i int) error {
+		log.Printf("[DEBUG] Getting User Tokens for User %q", emails[i])
+		resp, err := tokensService.List(emails[i]).Context(ctx).Do()
+		if isNotFound(err) {
+			// the user was deleted since it was listed
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error retrieving tokens for user %s: %w", emails[i], err)
+		}
+
+		// each user is only written by a single call, so no locking is required
+		tokens[i] = resp.Items
+
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("clients", aggregateOAuthClients(emails, tokens)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("users_scanned", len(emails))
+
+	d.SetId("oauth_clients")
+
+	log.Printf("[DEBUG] Finished getting OAuth Clients for %d users", len(emails))
+
+	return diags
+}
+
+// aggregateOAuthClients groups the tokens of each user, where tokens[i] are the tokens of emails[i],
+// by client ID, merging the granted scopes and counting the users.
+func aggregateOAuthClients(emails []string, tokens [][]*directory.Token) []interface{} {
+	type oauthClient struct {
+		token  *directory.Token
+		scopes map[string]bool
+		users  map[string]bool
+	}
+
+	clients := map[string]*oauthClient{}
+	for i, userTokens := range tokens {
+		for _, token := range userTokens {
+			c, ok := clients[token.ClientId]
+			if !ok {
+				c = &oauthClient{token: token, scopes: map[string]bool{}, users: map[string]bool{}}
+				clients[token.ClientId] = c
+			}
+
+			for _, scope := range token.Scopes {
+				c.scopes[scope] = true
+			}
+			c.users[emails[i]] = true
+		}
+	}
+
+	sortedKeys := func(m map[string]bool) []string {
+		keys := []string{}
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		return keys
+	}
+
+	clientIds := []string{}
+	for clientId := range clients {
+		clientIds = append(clientIds, clientId)
+	}
+	sort.Strings(clientIds)
+
+	result := []interface{}{}
+	for _, clientId := range clientIds {
+		c := clients[clientId]
+		result = append(result, map[string]interface{}{
+			"client_id":    clientId,
+			"display_text": c.token.DisplayText,
+			"anonymous":    c.token.Anonymous,
+			"native_app":   c.token.NativeApp,
+			"scopes":       sortedKeys(c.scopes),
+			"user_count":   len(c.users),
+			"users":        sortedKeys(c.users),
+		})
+	}
+
+	return result
+}
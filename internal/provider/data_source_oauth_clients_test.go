@@ -0,0 +1,125 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func TestAggregateOAuthClients(t *testing.T) {
+	emails := []string{"jane@example.com", "john@example.com", "gone@example.com"}
+	tokens := [][]*directory.Token{
+		{
+			{ClientId: "b", DisplayText: "Board", Scopes: []string{"email", "profile"}},
+			{ClientId: "a", DisplayText: "App", Scopes: []string{"drive"}, NativeApp: true},
+		},
+		{
+			{ClientId: "b", DisplayText: "Board", Scopes: []string{"calendar", "email"}},
+		},
+		nil,
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"client_id":    "a",
+			"display_text": "App",
+			"anonymous":    false,
+			"native_app":   true,
+			"scopes":       []string{"drive"},
+			"user_count":   1,
+			"users":        []string{"jane@example.com"},
+		},
+		map[string]interface{}{
+			"client_id":    "b",
+			"display_text": "Board",
+			"anonymous":    false,
+			"native_app":   false,
+			"scopes":       []string{"calendar", "email", "profile"},
+			"user_count":   2,
+			"users":        []string{"jane@example.com", "john@example.com"},
+		},
+	}
+
+	if actual := aggregateOAuthClients(emails, tokens); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %+v, got %+v", expected, actual)
+	}
+}
+
+func TestDataSourceOAuthClientsRead(t *testing.T) {
+	client, _ := newFakeApiServer(t, "/admin/directory/v1", func(w http.ResponseWriter, r *http.Request, path string) {
+		switch path {
+		case "/users":
+			if r.URL.Query().Get("pageToken") == "" {
+				fmt.Fprint(w, `{"users": [{"primaryEmail": "jane@example.com"}], "nextPageToken": "next"}`)
+			} else {
+				fmt.Fprint(w, `{"users": [{"primaryEmail": "john@example.com"}, {"primaryEmail": "gone@example.com"}]}`)
+			}
+		case "/users/jane@example.com/tokens":
+			fmt.Fprint(w, `{"items": [{"clientId": "a", "displayText": "App", "scopes": ["drive"]}]}`)
+		case "/users/john@example.com/tokens":
+			fmt.Fprint(w, `{"items": [{"clientId": "a", "displayText": "App", "scopes": ["email"]}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "Resource Not Found: userKey"}}`)
+		}
+	})
+	client.Customer = "my_customer"
+
+	d := schema.TestResourceDataRaw(t, dataSourceOAuthClients().Schema, map[string]interface{}{
+		"concurrency": 2,
+	})
+
+	diags := dataSourceOAuthClientsRead(context.Background(), d, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("users_scanned").(int) != 3 {
+		t.Errorf("expected 3 users to be scanned, got %d", d.Get("users_scanned"))
+	}
+
+	if d.Get("clients.#").(int) != 1 || d.Get("clients.0.user_count").(int) != 2 {
+		t.Fatalf("expected a single client used by 2 users, got %+v", d.Get("clients"))
+	}
+
+	expectedScopes := []interface{}{"drive", "email"}
+	if actual := d.Get("clients.0.scopes").([]interface{}); !reflect.DeepEqual(actual, expectedScopes) {
+		t.Errorf("expected scopes %v, got %v", expectedScopes, actual)
+	}
+}
+
+func TestAccDataSourceOAuthClients_basic(t *testing.T) {
+	t.Parallel()
+
+	if os.Getenv("GOOGLEWORKSPACE_DOMAIN") == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceOAuthClients_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.googleworkspace_oauth_clients.all", "users_scanned"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceOAuthClients_basic() string {
+	return `
+data "googleworkspace_oauth_clients" "all" {
+  concurrency = 5
+}
+`
+}
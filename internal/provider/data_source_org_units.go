@@ -0,0 +1,393 @@
+package googleworkspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/chromepolicy/v1"
+)
+
+func dataSourceOrgUnits() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Org Units data source returns the org unit hierarchy of the customer, optionally with the " +
+			"number of users and Chrome OS devices in each org unit and the effective values of Chrome policies. " +
+			"Org Units resides under the `https://www.googleapis.com/auth/admin.directory.orgunit` client scope, " +
+			"counts additionally require the `https://www.googleapis.com/auth/admin.directory.user.readonly` and " +
+			"`https://www.googleapis.com/auth/admin.directory.device.chromeos.readonly` client scopes, and policies " +
+			"the `https://www.googleapis.com/auth/chrome.management.policy` client scope.",
+
+		ReadContext: dataSourceOrgUnitsRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_unit_path": {
+				Description: "The full path of the org unit the hierarchy is returned for.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "/",
+			},
+			"type": {
+				Description: "Whether all sub-org units (`all`), only the immediate children " +
+					"(`children`), or all sub-org units and the org unit of `org_unit_path` itself " +
+					"(`allIncludingParent`) are returned.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "all",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice([]string{"all", "children", "allIncludingParent"}, false),
+				),
+			},
+			"include_counts": {
+				Description: "If `true`, the number of users and Chrome OS devices directly " +
+					"in each org unit is returned.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"policy_schemas": {
+				Description: "The Chrome policy schemas to resolve for every org unit, e.g. " +
+					"`chrome.users.MaxConnectionsPerProxy` or `chrome.users.*`. If set, the effective values " +
+					"are returned in `policies`, with whether they are inherited or explicitly set.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"concurrency": {
+				Description:      "The maximum number of policy requests made in parallel.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          5,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 20)),
+			},
+			"org_units": {
+				Description: "The org units, sorted by path.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"org_unit_id": {
+							Description: "The unique ID of the organizational unit.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"org_unit_path": {
+							Description: "The full path to the organizational unit.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The organizational unit's path name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"description": {
+							Description: "Description of the organizational unit.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"parent_org_unit_id": {
+							Description: "The unique ID of the parent organizational unit.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"parent_org_unit_path": {
+							Description: "The organizational unit's parent path.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"block_inheritance": {
+							Description: "Determines if a sub-organizational unit can inherit the settings of the " +
+								"parent organization.",
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"user_count": {
+							Description: "The number of users directly in the org unit. Only set if " +
+								"`include_counts` is `true`.",
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"device_count": {
+							Description: "The number of Chrome OS devices directly in the org unit. Only set if " +
+								"`include_counts` is `true`.",
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"policies": {
+							Description: "The effective values of the policies of `policy_schemas`, sorted by " +
+								"schema name.",
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"schema_name": {
+										Description: "The full qualified name of the policy schema.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"schema_values": {
+										Description: "JSON encoded map that represents key/value pairs of the " +
+											"effective value.",
+										Type:     schema.TypeMap,
+										Computed: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"inherited": {
+										Description: "Whether the value is inherited rather than explicitly set " +
+											"on the org unit.",
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"source_org_unit_id": {
+										Description: "The ID of the org unit the value is set on. Empty if the " +
+											"value is the default value for the customer.",
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceOrgUnitsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	orgUnitPath := d.Get("org_unit_path").(string)
+	listType := d.Get("type").(string)
+	log.Printf("[DEBUG] Getting Org Units under %q (%s)", orgUnitPath, listType)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	orgUnitsService, diags := GetOrgUnitsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	resp, err := orgUnitsService.List(client.Customer).Type(listType).OrgUnitPath(orgUnitPath).Do()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	orgUnits := resp.OrganizationUnits
+	sort.Slice(orgUnits, func(i, j int) bool {
+		return orgUnits[i].OrgUnitPath < orgUnits[j].OrgUnitPath
+	})
+
+	result := flattenOrgUnitsList(orgUnits)
+
+	if d.Get("include_counts").(bool) {
+		userCounts, deviceCounts, diags := countOrgUnitsMembers(ctx, directoryService, client.Customer, orgUnitPath)
+		if diags.HasError() {
+			return diags
+		}
+
+		for _, ou := range result {
+			path := strings.ToLower(ou["org_unit_path"].(string))
+			ou["user_count"] = userCounts[path]
+			ou["device_count"] = deviceCounts[path]
+		}
+	}
+
+	policySchemas := listOfInterfacestoStrings(d.Get("policy_schemas").([]interface{}))
+	if len(policySchemas) > 0 {
+		chromePolicyService, diags := client.NewChromePolicyService()
+		if diags.HasError() {
+			return diags
+		}
+
+		chromePoliciesService, diags := GetChromePoliciesService(chromePolicyService)
+		if diags.HasError() {
+			return diags
+		}
+
+		err := forEachConcurrently(ctx, len(result), d.Get("concurrency").(int), func(ctx context.Context, i int) error {
+			orgUnitId := strings.TrimPrefix(result[i]["org_unit_id"].(string), "id:")
+
+			var resolved []*chromepolicy.GoogleChromePolicyVersionsV1ResolvedPolicy
+			for _, schemaName := range policySchemas {
+				log.Printf("[DEBUG] Resolving Chrome Policy %q for org unit %q", schemaName, orgUnitId)
+				err := retryTimeDuration(ctx, chromePolicyRetryDuration, func() error {
+					// the pages of a failed attempt are discarded
+					var pages []*chromepolicy.GoogleChromePolicyVersionsV1ResolvedPolicy
+
+					retryErr := chromePoliciesService.Resolve(fmt.Sprintf("customers/%s", client.Customer), &chromepolicy.GoogleChromePolicyVersionsV1ResolveRequest{
+						PolicySchemaFilter: schemaName,
+						PolicyTargetKey: &chromepolicy.GoogleChromePolicyVersionsV1PolicyTargetKey{
+							TargetResource: "orgunits/" + orgUnitId,
+						},
+					}).Pages(ctx, func(resp *chromepolicy.GoogleChromePolicyVersionsV1ResolveResponse) error {
+						pages = append(pages, resp.ResolvedPolicies...)
+						return nil
+					})
+					if retryErr != nil {
+						return retryErr
+					}
+
+					resolved = append(resolved, pages...)
+					return nil
+				})
+				if err != nil {
+					return fmt.Errorf("could not resolve policy %s for org unit %s: %v", schemaName, orgUnitId, err)
+				}
+			}
+
+			policies, err := flattenOrgUnitResolvedPolicies(orgUnitId, resolved)
+			if err != nil {
+				return err
+			}
+
+			result[i]["policies"] = policies
+			return nil
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set("org_units", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("org_units/%s/%s", listType, strings.TrimPrefix(orgUnitPath, "/")))
+
+	log.Printf("[DEBUG] Finished getting %d Org Units under %q (%s)", len(result), orgUnitPath, listType)
+
+	return diags
+}
+
+// flattenOrgUnitsList returns the attributes of the org units, in the order given.
+func flattenOrgUnitsList(orgUnits []*directory.OrgUnit) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(orgUnits))
+	for _, ou := range orgUnits {
+		result = append(result, map[string]interface{}{
+			"org_unit_id":          ou.OrgUnitId,
+			"org_unit_path":        ou.OrgUnitPath,
+			"name":                 ou.Name,
+			"description":          ou.Description,
+			"parent_org_unit_id":   ou.ParentOrgUnitId,
+			"parent_org_unit_path": ou.ParentOrgUnitPath,
+			"block_inheritance":    ou.BlockInheritance,
+		})
+	}
+
+	return result
+}
+
+// countOrgUnitsMembers returns the number of users and Chrome OS devices directly in each org unit under
+// orgUnitPath, keyed by the lowercased org unit path as paths are case-insensitive.
+func countOrgUnitsMembers(ctx context.Context, directoryService *directory.Service, customer, orgUnitPath string) (map[string]int, map[string]int, diag.Diagnostics) {
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	chromeosDevicesService, diags := GetChromeosDevicesService(directoryService)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	userCounts := map[string]int{}
+	usersCall := usersService.List().Customer(customer).Fields("nextPageToken", "users(orgUnitPath)")
+	if orgUnitPath != "/" {
+		// the query matches the users of the org unit and of its sub-org units
+		usersCall = usersCall.Query(orgUnitPathQuery(orgUnitPath))
+	}
+	err := usersCall.Pages(ctx, func(resp *directory.Users) error {
+		for _, user := range resp.Users {
+			userCounts[strings.ToLower(user.OrgUnitPath)]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, diag.FromErr(err)
+	}
+
+	deviceCounts := map[string]int{}
+	err = chromeosDevicesService.List(customer).OrgUnitPath(orgUnitPath).IncludeChildOrgunits(true).
+		Fields("nextPageToken", "chromeosdevices(orgUnitPath)").Pages(ctx, func(resp *directory.ChromeOsDevices) error {
+		for _, device := range resp.Chromeosdevices {
+			deviceCounts[strings.ToLower(device.OrgUnitPath)]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, diag.FromErr(err)
+	}
+
+	return userCounts, deviceCounts, nil
+}
+
+// flattenOrgUnitResolvedPolicies returns the resolved policies of an org unit sorted by schema name. A
+// value is inherited when it's not set on the org unit itself.
+func flattenOrgUnitResolvedPolicies(orgUnitId string, resolved []*chromepolicy.GoogleChromePolicyVersionsV1ResolvedPolicy) ([]map[string]interface{}, error) {
+	targetResource := "orgunits/" + orgUnitId
+
+	policies := []map[string]interface{}{}
+	for _, policy := range resolved {
+		if policy == nil || policy.Value == nil {
+			continue
+		}
+
+		var valuesObj map[string]interface{}
+		if len(policy.Value.Value) > 0 {
+			if err := json.Unmarshal(policy.Value.Value, &valuesObj); err != nil {
+				return nil, fmt.Errorf("could not decode value of policy %s: %v", policy.Value.PolicySchema, err)
+			}
+		}
+
+		schemaValues := map[string]interface{}{}
+		for k, v := range valuesObj {
+			jsonVal, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			schemaValues[k] = string(jsonVal)
+		}
+
+		sourceTarget, inherited := resolvedPolicySource(policy, targetResource)
+
+		policies = append(policies, map[string]interface{}{
+			"schema_name":        policy.Value.PolicySchema,
+			"schema_values":      schemaValues,
+			"inherited":          inherited,
+			"source_org_unit_id": strings.TrimPrefix(sourceTarget, "orgunits/"),
+		})
+	}
+
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i]["schema_name"].(string) < policies[j]["schema_name"].(string)
+	})
+
+	return policies, nil
+}
@@ -0,0 +1,127 @@
+package googleworkspace
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"google.golang.org/api/chromepolicy/v1"
+)
+
+func TestFlattenOrgUnitResolvedPolicies(t *testing.T) {
+	resolved := []*chromepolicy.GoogleChromePolicyVersionsV1ResolvedPolicy{
+		{
+			SourceKey: &chromepolicy.GoogleChromePolicyVersionsV1PolicyTargetKey{TargetResource: "orgunits/parent"},
+			Value: &chromepolicy.GoogleChromePolicyVersionsV1PolicyValue{
+				PolicySchema: "chrome.users.MaxConnectionsPerProxy",
+				Value:        []byte(`{"maxConnectionsPerProxy":34}`),
+			},
+		},
+		{
+			SourceKey: &chromepolicy.GoogleChromePolicyVersionsV1PolicyTargetKey{TargetResource: "orgunits/child"},
+			Value: &chromepolicy.GoogleChromePolicyVersionsV1PolicyValue{
+				PolicySchema: "chrome.users.IncognitoModeAvailability",
+				Value:        []byte(`{"incognitoModeAvailability": "INCOGNITO_MODE_AVAILABILITY_DISABLED"}`),
+			},
+		},
+		{
+			Value: &chromepolicy.GoogleChromePolicyVersionsV1PolicyValue{
+				PolicySchema: "chrome.users.SafeBrowsingProtectionLevel",
+			},
+		},
+		nil,
+	}
+
+	got, err := flattenOrgUnitResolvedPolicies("child", resolved)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []map[string]interface{}{
+		{
+			"schema_name":        "chrome.users.IncognitoModeAvailability",
+			"schema_values":      map[string]interface{}{"incognitoModeAvailability": `"INCOGNITO_MODE_AVAILABILITY_DISABLED"`},
+			"inherited":          false,
+			"source_org_unit_id": "child",
+		},
+		{
+			"schema_name":        "chrome.users.MaxConnectionsPerProxy",
+			"schema_values":      map[string]interface{}{"maxConnectionsPerProxy": "34"},
+			"inherited":          true,
+			"source_org_unit_id": "parent",
+		},
+		{
+			"schema_name":        "chrome.users.SafeBrowsingProtectionLevel",
+			"schema_values":      map[string]interface{}{},
+			"inherited":          true,
+			"source_org_unit_id": "",
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestFlattenOrgUnitResolvedPolicies_invalidValue(t *testing.T) {
+	resolved := []*chromepolicy.GoogleChromePolicyVersionsV1ResolvedPolicy{
+		{
+			Value: &chromepolicy.GoogleChromePolicyVersionsV1PolicyValue{
+				PolicySchema: "chrome.users.MaxConnectionsPerProxy",
+				Value:        []byte(`[34]`),
+			},
+		},
+	}
+
+	if _, err := flattenOrgUnitResolvedPolicies("child", resolved); err == nil {
+		t.Errorf("expected an error for a value that is not an object")
+	}
+}
+
+func TestAccDataSourceOrgUnits_basic(t *testing.T) {
+	t.Parallel()
+
+	ouName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceOrgUnits_basic(ouName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.googleworkspace_org_units.tree", "org_units.#", "2"),
+					resource.TestCheckResourceAttr("data.googleworkspace_org_units.tree", "org_units.0.org_unit_path", "/"+ouName),
+					resource.TestCheckResourceAttr("data.googleworkspace_org_units.tree", "org_units.0.user_count", "0"),
+					resource.TestCheckResourceAttr("data.googleworkspace_org_units.tree", "org_units.1.parent_org_unit_path", "/"+ouName),
+					resource.TestCheckResourceAttr("data.googleworkspace_org_units.tree", "org_units.1.block_inheritance", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceOrgUnits_basic(ouName string) string {
+	return fmt.Sprintf(`
+resource "googleworkspace_org_unit" "parent" {
+  name                 = "%[1]s"
+  parent_org_unit_path = "/"
+}
+
+resource "googleworkspace_org_unit" "child" {
+  name                 = "child"
+  parent_org_unit_path = googleworkspace_org_unit.parent.org_unit_path
+}
+
+data "googleworkspace_org_units" "tree" {
+  org_unit_path  = "/%[1]s"
+  type           = "allIncludingParent"
+  include_counts = true
+
+  depends_on = [googleworkspace_org_unit.child]
+}
+`, ouName)
+}
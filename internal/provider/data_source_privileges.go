@@ -110,3 +110,93 @@ func flattenAndPrunePrivileges(privileges []*directory.Privilege, duplicates map
 	}
 	return result
 }
+
+// privilegeKey identifies a privilege the way flattenAndPrunePrivileges does.
+func privilegeKey(privilegeName, serviceId string) string {
+	return privilegeName + ":" + serviceId
+}
+
+// privilegeIndex answers which privileges exist and which privileges a privilege implies.
+type privilegeIndex struct {
+	// known is the set of privilege keys
+	known map[string]bool
+	// serviceIds are the service IDs a privilege name exists for
+	serviceIds map[string][]string
+	// serviceNames are the service names of the service IDs
+	serviceNames map[string]string
+	// descendants are the flattened child privileges of a privilege key
+	descendants map[string][]interface{}
+}
+
+func newPrivilegeIndex(privileges []*directory.Privilege) *privilegeIndex {
+	index := &privilegeIndex{
+		known:        map[string]bool{},
+		serviceIds:   map[string][]string{},
+		serviceNames: map[string]string{},
+		descendants:  map[string][]interface{}{},
+	}
+
+	for _, p := range flattenAndPrunePrivileges(privileges, index.known) {
+		priv := p.(map[string]interface{})
+		name := priv["privilege_name"].(string)
+		index.serviceIds[name] = append(index.serviceIds[name], priv["service_id"].(string))
+		index.serviceNames[priv["service_id"].(string)] = priv["service_name"].(string)
+	}
+
+	index.addDescendants(privileges)
+
+	return index
+}
+
+func (p *privilegeIndex) addDescendants(privileges []*directory.Privilege) {
+	for _, priv := range privileges {
+		if len(priv.ChildPrivileges) == 0 {
+			continue
+		}
+
+		key := privilegeKey(priv.PrivilegeName, priv.ServiceId)
+		p.descendants[key] = append(p.descendants[key], flattenAndPrunePrivileges(priv.ChildPrivileges, make(map[string]bool))...)
+		p.addDescendants(priv.ChildPrivileges)
+	}
+}
+
+// isDescendant returns whether the privilege is a descendant of the ancestor privilege.
+func (p *privilegeIndex) isDescendant(key, ancestor string) bool {
+	for _, d := range p.descendants[ancestor] {
+		priv := d.(map[string]interface{})
+		if privilegeKey(priv["privilege_name"].(string), priv["service_id"].(string)) == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getPrivilegeIndex returns the privileges of the customer, which are only listed once per provider run.
+func getPrivilegeIndex(client *apiClient) (*privilegeIndex, diag.Diagnostics) {
+	client.privilegeIndexMu.Lock()
+	defer client.privilegeIndexMu.Unlock()
+
+	if client.privilegeIndex != nil {
+		return client.privilegeIndex, nil
+	}
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	privilegesService, diags := GetPrivilegesService(directoryService)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	privileges, err := privilegesService.List(client.Customer).Do()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	client.privilegeIndex = newPrivilegeIndex(privileges.Items)
+
+	return client.privilegeIndex, diags
+}
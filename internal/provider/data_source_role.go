@@ -14,6 +14,9 @@ func dataSourceRole() *schema.Resource {
 	rSchema := datasourceSchemaFromResourceSchema(resourceRole().Schema)
 	addRequiredFieldsToSchema(rSchema, "name")
 
+	// expand_children only applies when granting privileges
+	delete(rSchema, "expand_children")
+
 	return &schema.Resource{
 		Description: "Role data source in the Terraform Googleworkspace provider. Role resides " +
 			"under the `https://www.googleapis.com/auth/admin.directory.rolemanagement` client scope.",
@@ -13,6 +13,10 @@ func dataSourceSchema() *schema.Resource {
 	dsSchema := datasourceSchemaFromResourceSchema(resourceSchema().Schema)
 	addExactlyOneOfFieldsToSchema(dsSchema, "schema_id", "schema_name")
 
+	// these only apply when planning changes to the schema
+	delete(dsSchema, "allow_destructive_changes")
+	delete(dsSchema, "count_affected_users")
+
 	return &schema.Resource{
 		// This description is used by the documentation generator and the language server.
 		Description: "Schema data source in the Terraform Googleworkspace provider. Schema resides " +
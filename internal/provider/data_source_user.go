@@ -7,9 +7,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// userResourceOnlyFields are the arguments of the user resource that control how the user is
+// managed, and the attributes derived from them, which don't apply to the user data sources.
+var userResourceOnlyFields = []string{"deletion_policy", "transfer_recipient", "restore_if_deleted",
+	"password_write_only", "password_version", "hash_password", "ignored_custom_schemas",
+	"managed_attributes", "unmanaged_attributes", "owned_attributes", "manage_aliases"}
+
 func dataSourceUser() *schema.Resource {
 	// Generate datasource schema from resource
 	dsSchema := datasourceSchemaFromResourceSchema(resourceUser().Schema)
+	for _, k := range userResourceOnlyFields {
+		delete(dsSchema, k)
+	}
 	addExactlyOneOfFieldsToSchema(dsSchema, "id", "primary_email")
 
 	return &schema.Resource{
@@ -59,5 +68,5 @@ func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, meta interf
 		d.SetId(user.Id)
 	}
 
-	return resourceUserRead(ctx, d, meta)
+	return readUser(ctx, d, meta, false)
 }
@@ -0,0 +1,113 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataSourceUserAsps() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "User ASPs data source lists the application-specific passwords (ASPs) of a Google Workspace " +
+			"User. User ASPs resides under the `https://www.googleapis.com/auth/admin.directory.user.security` " +
+			"client scope.",
+
+		ReadContext: dataSourceUserAspsRead,
+
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Description: "Identifies the user in the API request. The value can be the user's primary email " +
+					"address, alias email address, or unique user ID.",
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"asps": {
+				Description: "The application-specific passwords of the user.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"code_id": {
+							Description: "The unique ID of the ASP.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The name of the application that the user, represented by their userId, " +
+								"entered when the ASP was created.",
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"creation_time": {
+							Description: "The time when the ASP was created, in RFC 3339 format.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_time_used": {
+							Description: "The time when the ASP was last used, in RFC 3339 format. Empty if the " +
+								"ASP has never been used.",
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUserAspsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	log.Printf("[DEBUG] Getting User ASPs for User %q", userId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	aspsService, diags := GetAspsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	asps, err := aspsService.List(userId).Context(ctx).Do()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("asps", flattenAsps(asps.Items)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("users/%s/asps", userId))
+
+	log.Printf("[DEBUG] Finished getting User ASPs for User %q", userId)
+
+	return diags
+}
+
+func flattenAsps(asps []*directory.Asp) []interface{} {
+	result := []interface{}{}
+
+	for _, asp := range asps {
+		result = append(result, map[string]interface{}{
+			"code_id":        int(asp.CodeId),
+			"name":           asp.Name,
+			"creation_time":  formatUnixMilli(asp.CreationTime),
+			"last_time_used": formatUnixMilli(asp.LastTimeUsed),
+		})
+	}
+
+	return result
+}
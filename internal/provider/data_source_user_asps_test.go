@@ -0,0 +1,83 @@
+package googleworkspace
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func TestFlattenAsps(t *testing.T) {
+	asps := []*directory.Asp{
+		{CodeId: 1, Name: "Mail", CreationTime: 1700000000000, LastTimeUsed: 0},
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"code_id":        1,
+			"name":           "Mail",
+			"creation_time":  "2023-11-14T22:13:20Z",
+			"last_time_used": "",
+		},
+	}
+
+	if actual := flattenAsps(asps); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %+v, got %+v", expected, actual)
+	}
+}
+
+func TestAccDataSourceUserAsps_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testUserVals := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"password":   acctest.RandString(10),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceUserAsps_basic(testUserVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.googleworkspace_user_asps.asps", "asps.#", "0"),
+					resource.TestCheckResourceAttr("data.googleworkspace_user_tokens.tokens", "tokens.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceUserAsps_basic(testUserVals map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_user" "user" {
+  primary_email = "%{userEmail}@%{domainName}"
+  password      = "%{password}"
+
+  name {
+    family_name = "Scott"
+    given_name  = "Michael"
+  }
+}
+
+data "googleworkspace_user_asps" "asps" {
+  user_id = googleworkspace_user.user.id
+}
+
+data "googleworkspace_user_tokens" "tokens" {
+  user_id = googleworkspace_user.user.id
+}
+`, testUserVals)
+}
@@ -0,0 +1,120 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataSourceUserTokens() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "User Tokens data source lists the OAuth tokens a Google Workspace User has issued to " +
+			"third-party applications. User Tokens resides under the " +
+			"`https://www.googleapis.com/auth/admin.directory.user.security` client scope.",
+
+		ReadContext: dataSourceUserTokensRead,
+
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Description: "Identifies the user in the API request. The value can be the user's primary email " +
+					"address, alias email address, or unique user ID.",
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"tokens": {
+				Description: "The OAuth tokens issued by the user.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"client_id": {
+							Description: "The Client ID of the application the token is issued to.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"display_text": {
+							Description: "The displayable name of the application the token is issued to.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"scopes": {
+							Description: "A list of authorization scopes the application is granted.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"anonymous": {
+							Description: "Whether the application is registered with Google.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"native_app": {
+							Description: "Whether the token is issued to an installed application.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUserTokensRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	log.Printf("[DEBUG] Getting User Tokens for User %q", userId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	tokensService, diags := GetTokensService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	tokens, err := tokensService.List(userId).Context(ctx).Do()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("tokens", flattenTokens(tokens.Items)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("users/%s/tokens", userId))
+
+	log.Printf("[DEBUG] Finished getting User Tokens for User %q", userId)
+
+	return diags
+}
+
+func flattenTokens(tokens []*directory.Token) []interface{} {
+	result := []interface{}{}
+
+	for _, token := range tokens {
+		result = append(result, map[string]interface{}{
+			"client_id":    token.ClientId,
+			"display_text": token.DisplayText,
+			"scopes":       token.Scopes,
+			"anonymous":    token.Anonymous,
+			"native_app":   token.NativeApp,
+		})
+	}
+
+	return result
+}
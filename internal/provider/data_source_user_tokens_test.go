@@ -0,0 +1,32 @@
+package googleworkspace
+
+import (
+	"reflect"
+	"testing"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func TestFlattenTokens(t *testing.T) {
+	tokens := []*directory.Token{
+		{ClientId: "123.apps.googleusercontent.com", DisplayText: "App", Scopes: []string{"email"}, NativeApp: true},
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"client_id":    "123.apps.googleusercontent.com",
+			"display_text": "App",
+			"scopes":       []string{"email"},
+			"anonymous":    false,
+			"native_app":   true,
+		},
+	}
+
+	if actual := flattenTokens(tokens); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %+v, got %+v", expected, actual)
+	}
+
+	if actual := flattenTokens(nil); len(actual) != 0 {
+		t.Errorf("expected no tokens, got %+v", actual)
+	}
+}
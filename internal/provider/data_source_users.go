@@ -10,6 +10,9 @@ import (
 func dataSourceUsers() *schema.Resource {
 	// Generate datasource schema from resource
 	dsUserSchema := datasourceSchemaFromResourceSchema(resourceUser().Schema)
+	for _, k := range userResourceOnlyFields {
+		delete(dsUserSchema, k)
+	}
 
 	return &schema.Resource{
 		// This description is used by the documentation generator and the language server.
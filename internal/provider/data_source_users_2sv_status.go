@@ -0,0 +1,171 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataSourceUsers2svStatus() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Users 2SV Status data source summarizes the 2-Step Verification (2SV) enrollment and " +
+			"enforcement of the users of the customer, or of an org unit, for compliance checks. Users 2SV Status " +
+			"resides under the `https://www.googleapis.com/auth/admin.directory.user.readonly` client scope.",
+
+		ReadContext: dataSourceUsers2svStatusRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_unit_path": {
+				Description: "The full path of an org unit. If set, only the users of the org unit and its " +
+					"sub-org units are included.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"include_suspended": {
+				Description: "If `true`, suspended users are included.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"total_users": {
+				Description: "The number of users included.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"enrolled_count": {
+				Description: "The number of users enrolled in 2SV.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"not_enrolled_count": {
+				Description: "The number of users not enrolled in 2SV.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"enforced_count": {
+				Description: "The number of users for whom 2SV is enforced.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"not_enforced_count": {
+				Description: "The number of users for whom 2SV is not enforced.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"not_enrolled_users": {
+				Description: "The primary emails of the users not enrolled in 2SV, sorted.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"not_enforced_users": {
+				Description: "The primary emails of the users for whom 2SV is not enforced, sorted.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUsers2svStatusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	orgUnitPath := d.Get("org_unit_path").(string)
+	log.Printf("[DEBUG] Getting Users 2SV Status for org unit %q", orgUnitPath)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	listCall := usersService.List().Customer(client.Customer).
+		Fields("nextPageToken", "users(primaryEmail,suspended,isEnrolledIn2Sv,isEnforcedIn2Sv)")
+
+	if orgUnitPath != "" {
+		listCall = listCall.Query(orgUnitPathQuery(orgUnitPath))
+	}
+
+	var result []*directory.User
+	err := listCall.Pages(ctx, func(resp *directory.Users) error {
+		result = append(result, resp.Users...)
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for k, v := range summarizeUsers2svStatus(result, d.Get("include_suspended").(bool)) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if orgUnitPath == "" {
+		d.SetId("users_2sv_status")
+	} else {
+		d.SetId(fmt.Sprintf("users_2sv_status/%s", orgUnitPath))
+	}
+
+	log.Printf("[DEBUG] Finished getting Users 2SV Status for org unit %q", orgUnitPath)
+
+	return diags
+}
+
+// summarizeUsers2svStatus counts the users enrolled in and enforced in 2SV, and lists those that are not.
+func summarizeUsers2svStatus(users []*directory.User, includeSuspended bool) map[string]interface{} {
+	total, enrolled, enforced := 0, 0, 0
+	notEnrolled := []string{}
+	notEnforced := []string{}
+
+	for _, user := range users {
+		if user.Suspended && !includeSuspended {
+			continue
+		}
+		total++
+
+		if user.IsEnrolledIn2Sv {
+			enrolled++
+		} else {
+			notEnrolled = append(notEnrolled, user.PrimaryEmail)
+		}
+
+		if user.IsEnforcedIn2Sv {
+			enforced++
+		} else {
+			notEnforced = append(notEnforced, user.PrimaryEmail)
+		}
+	}
+
+	sort.Strings(notEnrolled)
+	sort.Strings(notEnforced)
+
+	return map[string]interface{}{
+		"total_users":        total,
+		"enrolled_count":     enrolled,
+		"not_enrolled_count": total - enrolled,
+		"enforced_count":     enforced,
+		"not_enforced_count": total - enforced,
+		"not_enrolled_users": notEnrolled,
+		"not_enforced_users": notEnforced,
+	}
+}
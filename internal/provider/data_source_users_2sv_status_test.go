@@ -0,0 +1,68 @@
+package googleworkspace
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func TestSummarizeUsers2svStatus(t *testing.T) {
+	users := []*directory.User{
+		{PrimaryEmail: "kevin@example.com", IsEnrolledIn2Sv: false, IsEnforcedIn2Sv: false},
+		{PrimaryEmail: "angela@example.com", IsEnrolledIn2Sv: true, IsEnforcedIn2Sv: true},
+		{PrimaryEmail: "oscar@example.com", IsEnrolledIn2Sv: true, IsEnforcedIn2Sv: false},
+		{PrimaryEmail: "creed@example.com", IsEnrolledIn2Sv: false, IsEnforcedIn2Sv: true},
+		{PrimaryEmail: "toby@example.com", Suspended: true},
+	}
+
+	expected := map[string]interface{}{
+		"total_users":        4,
+		"enrolled_count":     2,
+		"not_enrolled_count": 2,
+		"enforced_count":     2,
+		"not_enforced_count": 2,
+		"not_enrolled_users": []string{"creed@example.com", "kevin@example.com"},
+		"not_enforced_users": []string{"kevin@example.com", "oscar@example.com"},
+	}
+
+	if actual := summarizeUsers2svStatus(users, false); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %+v, got %+v", expected, actual)
+	}
+
+	if actual := summarizeUsers2svStatus(users, true); actual["total_users"] != 5 || actual["not_enrolled_count"] != 3 {
+		t.Errorf("expected suspended users to be included, got %+v", actual)
+	}
+}
+
+func TestAccDataSourceUsers2svStatus_basic(t *testing.T) {
+	t.Parallel()
+
+	if os.Getenv("GOOGLEWORKSPACE_DOMAIN") == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceUsers2svStatus_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.googleworkspace_users_2sv_status.all", "total_users"),
+					resource.TestCheckResourceAttrSet("data.googleworkspace_users_2sv_status.all", "enrolled_count"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceUsers2svStatus_basic() string {
+	return `
+data "googleworkspace_users_2sv_status" "all" {
+  org_unit_path = "/"
+}
+`
+}
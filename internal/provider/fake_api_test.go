@@ -0,0 +1,94 @@
+package googleworkspace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+)
+
+// redirectTransport sends every request to the test server instead of the Google API host.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newFakeApiClient returns a client whose requests are all served by the handler.
+func newFakeApiClient(t *testing.T, handler http.Handler) *apiClient {
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	target, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &apiClient{
+		client: &http.Client{Transport: &redirectTransport{target: target}},
+	}
+}
+
+// newFakeApiServer returns a client for a fake Google API, whose requests are served by the handler
+// with the prefix trimmed from their path, and a func returning the requests received so far, as
+// "<method> <path>" followed by the body if there is one. Requests are served one at a time, so
+// that the handler can keep state, and responses are JSON.
+func newFakeApiServer(t *testing.T, prefix string, handler func(w http.ResponseWriter, r *http.Request, path string)) (*apiClient, func() []string) {
+	var mu sync.Mutex
+	var requests []string
+
+	client := newFakeApiClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, prefix)
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read the request body: %v", err)
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		request := fmt.Sprintf("%s %s", r.Method, path)
+		if b := strings.TrimSpace(string(body)); b != "" {
+			request += " " + b
+		}
+		requests = append(requests, request)
+
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, r, path)
+	}))
+
+	return client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string{}, requests...)
+	}
+}
+
+// newFakeGmailService returns a Gmail service sending its requests with the client of a fake API.
+// Resources impersonate the user with apiClient.NewGmailService, so tests call their helpers with
+// the service directly.
+func newFakeGmailService(t *testing.T, client *apiClient) *gmail.Service {
+	gmailService, err := gmail.NewService(context.Background(), option.WithHTTPClient(client.client))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return gmailService
+}
@@ -14,6 +14,7 @@ import (
 func getValuesToScrub() []string {
 	return []string{
 		"accessToken",
+		"password",
 	}
 }
 
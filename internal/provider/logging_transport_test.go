@@ -0,0 +1,51 @@
+package googleworkspace
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestLoggingTransport_scrubsPassword(t *testing.T) {
+	t.Setenv("TF_LOG", "DEBUG")
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(io.Discard)
+
+	transport := NewTransportWithScrubbedLogs("Google Workspace", roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"primaryEmail":"jane@example.com"}`)),
+			Request:    req,
+		}, nil
+	}))
+
+	body := `{"primaryEmail":"jane@example.com","password":"correct horse battery staple","hashFunction":"MD5"}`
+	req, err := http.NewRequest("PUT", "https://admin.googleapis.com/admin/directory/v1/users/jane", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !strings.Contains(logs.String(), "jane@example.com") {
+		t.Fatalf("expected the request to be logged, got %q", logs.String())
+	}
+
+	if strings.Contains(logs.String(), "correct horse battery staple") {
+		t.Errorf("expected the password to be scrubbed from the logs, got %q", logs.String())
+	}
+}
@@ -0,0 +1,211 @@
+package googleworkspace
+
+import (
+	"crypto/md5"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	passwordStateHashPrefix     = "pbkdf2-sha256"
+	passwordStateHashIterations = 10000
+	passwordStateHashSaltLength = 16
+)
+
+// hashPasswordForState returns a salted hash of the password, in the format
+// `pbkdf2-sha256$<iterations>$<salt>$<key>`, to be stored in the state in place of the password.
+func hashPasswordForState(password string) (string, error) {
+	salt := make([]byte, passwordStateHashSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	return passwordStateHash(password, salt, passwordStateHashIterations)
+}
+
+func passwordStateHash(password string, salt []byte, iterations int) (string, error) {
+	key, err := pbkdf2.Key(sha256.New, password, salt, iterations, sha256.Size)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join([]string{
+		passwordStateHashPrefix,
+		strconv.Itoa(iterations),
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	}, "$"), nil
+}
+
+// isPasswordStateHash returns whether the value was generated by hashPasswordForState.
+func isPasswordStateHash(value string) bool {
+	return strings.HasPrefix(value, passwordStateHashPrefix+"$")
+}
+
+// passwordMatchesStateHash returns whether the password hashes to the value generated by hashPasswordForState.
+func passwordMatchesStateHash(stateHash, password string) bool {
+	parts := strings.Split(stateHash, "$")
+	if len(parts) != 4 || parts[0] != passwordStateHashPrefix {
+		return false
+	}
+
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations < 1 {
+		return false
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+
+	expected, err := passwordStateHash(password, salt, iterations)
+	if err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(stateHash)) == 1
+}
+
+// hashUserPassword hashes the password with one of the hash functions supported by the Directory API,
+// hex-encoding MD5 and SHA-1 digests and using salted SHA-512 for crypt.
+func hashUserPassword(password, hashFunction string) (string, error) {
+	switch hashFunction {
+	case "MD5":
+		sum := md5.Sum([]byte(password))
+		return hex.EncodeToString(sum[:]), nil
+	case "SHA-1":
+		sum := sha1.Sum([]byte(password))
+		return hex.EncodeToString(sum[:]), nil
+	case "crypt":
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		for i, b := range salt {
+			salt[i] = cryptAlphabet[int(b)%len(cryptAlphabet)]
+		}
+
+		return sha512Crypt(password, string(salt)), nil
+	}
+
+	return "", fmt.Errorf("unsupported hash_function %q, expected one of MD5, SHA-1 or crypt", hashFunction)
+}
+
+const cryptAlphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// sha512Crypt implements the SHA-512 based crypt(3) scheme (`$6$`) with the default 5000 rounds,
+// as specified in https://www.akkadia.org/drepper/SHA-crypt.txt.
+func sha512Crypt(password, salt string) string {
+	const rounds = 5000
+
+	if len(salt) > 16 {
+		salt = salt[:16]
+	}
+	p := []byte(password)
+	s := []byte(salt)
+
+	b := sha512.New()
+	b.Write(p)
+	b.Write(s)
+	b.Write(p)
+	digestB := b.Sum(nil)
+
+	a := sha512.New()
+	a.Write(p)
+	a.Write(s)
+	for i := len(p); i > 0; i -= sha512.Size {
+		if i > sha512.Size {
+			a.Write(digestB)
+		} else {
+			a.Write(digestB[:i])
+		}
+	}
+	for i := len(p); i > 0; i >>= 1 {
+		if i&1 != 0 {
+			a.Write(digestB)
+		} else {
+			a.Write(p)
+		}
+	}
+	digestA := a.Sum(nil)
+
+	dp := sha512.New()
+	for i := 0; i < len(p); i++ {
+		dp.Write(p)
+	}
+	pBytes := repeatBytes(dp.Sum(nil), len(p))
+
+	ds := sha512.New()
+	for i := 0; i < 16+int(digestA[0]); i++ {
+		ds.Write(s)
+	}
+	sBytes := repeatBytes(ds.Sum(nil), len(s))
+
+	c := digestA
+	for i := 0; i < rounds; i++ {
+		h := sha512.New()
+		if i&1 != 0 {
+			h.Write(pBytes)
+		} else {
+			h.Write(c)
+		}
+		if i%3 != 0 {
+			h.Write(sBytes)
+		}
+		if i%7 != 0 {
+			h.Write(pBytes)
+		}
+		if i&1 != 0 {
+			h.Write(c)
+		} else {
+			h.Write(pBytes)
+		}
+		c = h.Sum(nil)
+	}
+
+	order := [][3]int{
+		{0, 21, 42}, {22, 43, 1}, {44, 2, 23}, {3, 24, 45}, {25, 46, 4}, {47, 5, 26}, {6, 27, 48},
+		{28, 49, 7}, {50, 8, 29}, {9, 30, 51}, {31, 52, 10}, {53, 11, 32}, {12, 33, 54}, {34, 55, 13},
+		{56, 14, 35}, {15, 36, 57}, {37, 58, 16}, {59, 17, 38}, {18, 39, 60}, {40, 61, 19}, {62, 20, 41},
+	}
+
+	var out strings.Builder
+	out.WriteString("$6$" + salt + "$")
+	encode := func(b2, b1, b0 byte, n int) {
+		w := uint(b2)<<16 | uint(b1)<<8 | uint(b0)
+		for ; n > 0; n-- {
+			out.WriteByte(cryptAlphabet[w&0x3f])
+			w >>= 6
+		}
+	}
+	for _, o := range order {
+		encode(c[o[0]], c[o[1]], c[o[2]], 4)
+	}
+	encode(0, 0, c[63], 2)
+
+	return out.String()
+}
+
+// repeatBytes repeats the digest until it is n bytes long.
+func repeatBytes(digest []byte, n int) []byte {
+	result := make([]byte, 0, n)
+	for len(result) < n {
+		remaining := n - len(result)
+		if remaining > len(digest) {
+			remaining = len(digest)
+		}
+		result = append(result, digest[:remaining]...)
+	}
+
+	return result
+}
@@ -0,0 +1,86 @@
+package googleworkspace
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSha512Crypt(t *testing.T) {
+	// test vectors from https://www.akkadia.org/drepper/SHA-crypt.txt
+	cases := []struct {
+		salt, password, expected string
+	}{
+		{
+			salt:     "saltstring",
+			password: "Hello world!",
+			expected: "$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1",
+		},
+	}
+
+	for _, c := range cases {
+		if actual := sha512Crypt(c.password, c.salt); actual != c.expected {
+			t.Errorf("sha512Crypt(%q, %q) = %q, expected %q", c.password, c.salt, actual, c.expected)
+		}
+	}
+}
+
+func TestHashUserPassword(t *testing.T) {
+	cases := map[string]string{
+		"MD5":   "5f4dcc3b5aa765d61d8327deb882cf99",
+		"SHA-1": "5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8",
+	}
+
+	for hashFunction, expected := range cases {
+		actual, err := hashUserPassword("password", hashFunction)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if actual != expected {
+			t.Errorf("%s: expected %q, got %q", hashFunction, expected, actual)
+		}
+	}
+
+	crypted, err := hashUserPassword("password", "crypt")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	parts := strings.Split(crypted, "$")
+	if len(parts) != 4 || parts[1] != "6" || sha512Crypt("password", parts[2]) != crypted {
+		t.Errorf("unexpected crypt hash %q", crypted)
+	}
+
+	if _, err := hashUserPassword("password", "SHA-256"); err == nil {
+		t.Errorf("expected an error for an unsupported hash function")
+	}
+}
+
+func TestPasswordStateHash(t *testing.T) {
+	hash, err := hashPasswordForState("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if strings.Contains(hash, "correct horse") || !isPasswordStateHash(hash) {
+		t.Errorf("unexpected state hash %q", hash)
+	}
+
+	if !passwordMatchesStateHash(hash, "correct horse battery staple") {
+		t.Errorf("expected the password to match its hash")
+	}
+
+	if passwordMatchesStateHash(hash, "correct horse battery stapler") {
+		t.Errorf("expected a different password to not match the hash")
+	}
+
+	if passwordMatchesStateHash("correct horse battery staple", "correct horse battery staple") {
+		t.Errorf("expected a plaintext state value to not match")
+	}
+
+	other, err := hashPasswordForState("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if other == hash {
+		t.Errorf("expected hashes of the same password to be salted differently")
+	}
+}
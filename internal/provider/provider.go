@@ -114,21 +114,29 @@ func New(version string) func() *schema.Provider {
 				},
 			},
 			DataSourcesMap: map[string]*schema.Resource{
+				"googleworkspace_admin_effective_privileges":            dataSourceAdminEffectivePrivileges(),
 				"googleworkspace_chrome_policy_schema":                  dataSourceChromePolicySchema(),
 				"googleworkspace_chrome_policy_group_priority_ordering": dataSourceChromePolicyGroupPriorityOrdering(),
 				"googleworkspace_domain":                                dataSourceDomain(),
 				"googleworkspace_domain_alias":                          dataSourceDomainAlias(),
+				"googleworkspace_domain_dns_records":                    dataSourceDomainDnsRecords(),
+				"googleworkspace_domain_verification_token":             dataSourceDomainVerificationToken(),
 				"googleworkspace_group":                                 dataSourceGroup(),
 				"googleworkspace_groups":                                dataSourceGroups(),
 				"googleworkspace_group_member":                          dataSourceGroupMember(),
 				"googleworkspace_group_members":                         dataSourceGroupMembers(),
 				"googleworkspace_group_settings":                        dataSourceGroupSettings(),
+				"googleworkspace_oauth_clients":                         dataSourceOAuthClients(),
 				"googleworkspace_org_unit":                              dataSourceOrgUnit(),
+				"googleworkspace_org_units":                             dataSourceOrgUnits(),
 				"googleworkspace_privileges":                            dataSourcePrivileges(),
 				"googleworkspace_role":                                  dataSourceRole(),
 				"googleworkspace_schema":                                dataSourceSchema(),
 				"googleworkspace_user":                                  dataSourceUser(),
+				"googleworkspace_user_asps":                             dataSourceUserAsps(),
+				"googleworkspace_user_tokens":                           dataSourceUserTokens(),
 				"googleworkspace_users":                                 dataSourceUsers(),
+				"googleworkspace_users_2sv_status":                      dataSourceUsers2svStatus(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
 				"googleworkspace_chrome_policy":                         resourceChromePolicy(),
@@ -137,17 +145,33 @@ func New(version string) func() *schema.Provider {
 				"googleworkspace_chrome_group_policy":                   resourceChromeGroupPolicy(),
 				"googleworkspace_domain":                                resourceDomain(),
 				"googleworkspace_domain_alias":                          resourceDomainAlias(),
+				"googleworkspace_domain_verification":                   resourceDomainVerification(),
+				"googleworkspace_gmail_delegate":                        resourceGmailDelegate(),
+				"googleworkspace_gmail_delegates":                       resourceGmailDelegates(),
+				"googleworkspace_gmail_filter":                          resourceGmailFilter(),
+				"googleworkspace_gmail_forwarding_address":              resourceGmailForwardingAddress(),
 				"googleworkspace_gmail_send_as_alias":                   resourceGmailSendAsAlias(),
+				"googleworkspace_gmail_settings":                        resourceGmailSettings(),
 				"googleworkspace_group":                                 resourceGroup(),
+				"googleworkspace_group_alias":                           resourceGroupAlias(),
 				"googleworkspace_group_member":                          resourceGroupMember(),
 				"googleworkspace_group_members":                         resourceGroupMembers(),
 				"googleworkspace_group_settings":                        resourceGroupSettings(),
 				"googleworkspace_group_dynamic":                         resourceGroupDynamic(),
 				"googleworkspace_org_unit":                              resourceOrgUnit(),
+				"googleworkspace_org_unit_members":                      resourceOrgUnitMembers(),
+				"googleworkspace_org_unit_tree":                         resourceOrgUnitTree(),
 				"googleworkspace_role":                                  resourceRole(),
 				"googleworkspace_role_assignment":                       resourceRoleAssignment(),
+				"googleworkspace_role_assignments":                      resourceRoleAssignments(),
 				"googleworkspace_schema":                                resourceSchema(),
+				"googleworkspace_two_step_verification":                 resourceTwoStepVerification(),
 				"googleworkspace_user":                                  resourceUser(),
+				"googleworkspace_user_alias":                            resourceUserAlias(),
+				"googleworkspace_user_custom_schema_values":             resourceUserCustomSchemaValues(),
+				"googleworkspace_user_photo":                            resourceUserPhoto(),
+				"googleworkspace_user_security_action":                  resourceUserSecurityAction(),
+				"googleworkspace_users_bulk":                            resourceUsersBulk(),
 			},
 		}
 
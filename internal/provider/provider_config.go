@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"sync"
 
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -11,6 +12,7 @@ import (
 	"golang.org/x/oauth2"
 	googleoauth "golang.org/x/oauth2/google"
 
+	datatransfer "google.golang.org/api/admin/datatransfer/v1"
 	directory "google.golang.org/api/admin/directory/v1"
 	"google.golang.org/api/chromepolicy/v1"
 	"google.golang.org/api/cloudidentity/v1"
@@ -18,6 +20,7 @@ import (
 	"google.golang.org/api/groupssettings/v1"
 	"google.golang.org/api/impersonate"
 	"google.golang.org/api/option"
+	"google.golang.org/api/siteverification/v1"
 	"google.golang.org/api/transport"
 )
 
@@ -31,6 +34,10 @@ type apiClient struct {
 	ImpersonatedUserEmail string
 	ServiceAccount        string
 	UserAgent             string
+
+	// privilegeIndex caches the privileges of the customer, see getPrivilegeIndex
+	privilegeIndexMu sync.Mutex
+	privilegeIndex   *privilegeIndex
 }
 
 func (c *apiClient) loadAndValidate(ctx context.Context) diag.Diagnostics {
@@ -188,6 +195,28 @@ func (c *apiClient) NewCloudIdentityService() (*cloudidentity.Service, diag.Diag
 	return cloudIdentityService, diags
 }
 
+func (c *apiClient) NewDataTransferService() (*datatransfer.Service, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	log.Printf("[INFO] Instantiating Google Admin Data Transfer service")
+
+	dataTransferService, err := datatransfer.NewService(context.Background(), option.WithHTTPClient(c.client))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	if dataTransferService == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Data Transfer Service could not be created.",
+		})
+
+		return nil, diags
+	}
+
+	return dataTransferService, diags
+}
+
 func (c *apiClient) NewDirectoryService() (*directory.Service, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
@@ -267,3 +296,25 @@ func (c *apiClient) NewGroupsSettingsService() (*groupssettings.Service, diag.Di
 
 	return groupsSettingsService, diags
 }
+
+func (c *apiClient) NewSiteVerificationService() (*siteverification.Service, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	log.Printf("[INFO] Instantiating Google Site Verification service")
+
+	siteVerificationService, err := siteverification.NewService(context.Background(), option.WithHTTPClient(c.client))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	if siteVerificationService == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Site Verification Service could not be created.",
+		})
+
+		return nil, diags
+	}
+
+	return siteVerificationService, diags
+}
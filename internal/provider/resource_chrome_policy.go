@@ -637,8 +637,8 @@ func resourceChromePolicyImport(ctx context.Context, d *schema.ResourceData, met
 		// Inherited policies are allowed (they have valid values) but logged as warnings.
 		// After import, Terraform will manage them: if the config matches the inherited
 		// value there's no change; if it differs, the next apply will set it explicitly.
-		sourceTarget := resp.ResolvedPolicies[0].SourceKey.TargetResource
-		if sourceTarget != expectedTargetResource {
+		sourceTarget, inherited := resolvedPolicySource(resp.ResolvedPolicies[0], expectedTargetResource)
+		if inherited {
 			log.Printf("[WARN] Import: policy %s on %s is inherited from %s (not explicitly set). "+
 				"Terraform will manage this policy going forward.",
 				schemaName, expectedTargetResource, sourceTarget,
@@ -668,6 +668,17 @@ func resourceChromePolicyImport(ctx context.Context, d *schema.ResourceData, met
 	return []*schema.ResourceData{d}, nil
 }
 
+// resolvedPolicySource returns the target resource that the value of the resolved policy is set on, from
+// its sourceKey, and whether the value is inherited, i.e. not set on targetResource itself.
+func resolvedPolicySource(policy *chromepolicy.GoogleChromePolicyVersionsV1ResolvedPolicy, targetResource string) (string, bool) {
+	sourceTarget := ""
+	if policy.SourceKey != nil {
+		sourceTarget = policy.SourceKey.TargetResource
+	}
+
+	return sourceTarget, sourceTarget != targetResource
+}
+
 // Chrome Policies
 
 func validateChromePolicies(ctx context.Context, d *schema.ResourceData, client *apiClient) diag.Diagnostics {
@@ -0,0 +1,200 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"google.golang.org/api/siteverification/v1"
+)
+
+func resourceDomainVerification() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Domain Verification resource verifies the ownership of a domain with the Site Verification " +
+			"API, so that a domain added with `googleworkspace_domain` becomes verified. The DNS record of the " +
+			"`googleworkspace_domain_verification_token` data source has to be created first, the verification " +
+			"is retried until it's found or the create timeout is reached. Destroying the resource keeps the " +
+			"domain verified. Domain Verification resides under the " +
+			"`https://www.googleapis.com/auth/siteverification` client scope.",
+
+		CreateContext: resourceDomainVerificationCreate,
+		ReadContext:   resourceDomainVerificationRead,
+		DeleteContext: resourceDomainVerificationDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDomainVerificationImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"domain_name": {
+				Description: "The domain name to verify.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"verification_method": {
+				Description: "The verification method, `DNS_TXT` or `DNS_CNAME`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "DNS_TXT",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice(domainVerificationMethods, false),
+				),
+			},
+			"token": {
+				Description: "The verification token the domain was verified with.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"owners": {
+				Description: "The email addresses of all verified owners of the domain.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceDomainVerificationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	domainName := d.Get("domain_name").(string)
+	method := d.Get("verification_method").(string)
+	log.Printf("[DEBUG] Creating Domain Verification %q (%s)", domainName, method)
+
+	siteVerificationService, diags := client.NewSiteVerificationService()
+	if diags.HasError() {
+		return diags
+	}
+
+	webResourceService, diags := GetWebResourceService(siteVerificationService)
+	if diags.HasError() {
+		return diags
+	}
+
+	token, err := getDomainVerificationToken(webResourceService, domainName, method)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("token", token)
+
+	var webResource *siteverification.SiteVerificationWebResourceResource
+	err = retryTimeDuration(ctx, d.Timeout(schema.TimeoutCreate), func() error {
+		resp, retryErr := webResourceService.Insert(method, &siteverification.SiteVerificationWebResourceResource{
+			Site: &siteverification.SiteVerificationWebResourceResourceSite{
+				Identifier: domainName,
+				Type:       "INET_DOMAIN",
+			},
+		}).Do()
+		// the token isn't found until the DNS record has propagated, other bad requests won't succeed on a retry
+		if isDomainVerificationTokenNotFound(retryErr) {
+			return fmt.Errorf("timed out while waiting for the verification token of %s to be found: %s", domainName, retryErr)
+		}
+		if retryErr != nil {
+			return retryErr
+		}
+
+		webResource = resp
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(webResource.Id)
+	log.Printf("[DEBUG] Finished creating Domain Verification %q: %s", d.Id(), domainName)
+
+	return resourceDomainVerificationRead(ctx, d, meta)
+}
+
+// isDomainVerificationTokenNotFound returns true for the 400 error returned by webResource.Insert
+// while the verification token can't be found on the site yet.
+func isDomainVerificationTokenNotFound(err error) bool {
+	return isApiErrorWithCode(err, 400) && strings.Contains(strings.ToLower(err.Error()), "verification token could not be found")
+}
+
+func resourceDomainVerificationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	siteVerificationService, diags := client.NewSiteVerificationService()
+	if diags.HasError() {
+		return diags
+	}
+
+	webResourceService, diags := GetWebResourceService(siteVerificationService)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Getting Domain Verification %q", d.Id())
+
+	// the client escapes the ID, which the API returns escaped already
+	id, err := url.PathUnescape(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	webResource, err := webResourceService.Get(id).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Get("domain_name").(string))
+	}
+
+	if webResource.Site != nil {
+		d.Set("domain_name", webResource.Site.Identifier)
+	}
+	d.Set("owners", webResource.Owners)
+
+	log.Printf("[DEBUG] Finished getting Domain Verification %q", d.Id())
+
+	return diags
+}
+
+func resourceDomainVerificationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Deleting the web resource would remove the ownership of the domain, which an added domain
+	// relies on, so the verification is only removed from the state.
+	log.Printf("[DEBUG] Removing Domain Verification %q from state", d.Id())
+	d.SetId("")
+
+	return diags
+}
+
+// resourceDomainVerificationImport accepts the domain name, as well as the ID of the web resource.
+func resourceDomainVerificationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if !strings.Contains(d.Id(), "%") && !strings.Contains(d.Id(), "://") {
+		d.SetId(url.QueryEscape("dns://" + d.Id()))
+	}
+
+	// the verification method is not returned in the response, so default it on import
+	d.Set("verification_method", "DNS_TXT")
+
+	return []*schema.ResourceData{d}, nil
+}
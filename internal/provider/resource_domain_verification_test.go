@@ -0,0 +1,148 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceDomainVerificationImport(t *testing.T) {
+	t.Parallel()
+
+	for id, want := range map[string]string{
+		"example.com":             "dns%3A%2F%2Fexample.com",
+		"dns%3A%2F%2Fexample.com": "dns%3A%2F%2Fexample.com",
+		"dns://example.com":       "dns://example.com",
+	} {
+		d := resourceDomainVerification().TestResourceData()
+		d.SetId(id)
+
+		if _, err := resourceDomainVerificationImport(context.Background(), d, nil); err != nil {
+			t.Fatalf("%s: unexpected error: %v", id, err)
+		}
+		if d.Id() != want || d.Get("verification_method") != "DNS_TXT" {
+			t.Errorf("%s: expected ID %s, got %s with verification method %v", id, want, d.Id(), d.Get("verification_method"))
+		}
+	}
+}
+
+func TestResourceDomainVerificationCreate(t *testing.T) {
+	inserts := 0
+
+	client, requests := newFakeApiServer(t, "/siteVerification/v1", func(w http.ResponseWriter, r *http.Request, path string) {
+		switch {
+		case r.Method == http.MethodPost && path == "/token":
+			fmt.Fprint(w, `{"method": "DNS_TXT", "token": "google-site-verification=abc"}`)
+		case r.Method == http.MethodPost && path == "/webResource":
+			inserts++
+			// the record hasn't propagated yet on the first attempt
+			if inserts == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error": {"code": 400, "message": "The necessary verification token could not be found on your site."}}`)
+				return
+			}
+			fmt.Fprint(w, `{"id": "dns%3A%2F%2Fexample.com", "site": {"type": "INET_DOMAIN", "identifier": "example.com"}, "owners": ["admin@example.com"]}`)
+		case r.Method == http.MethodGet && path == "/webResource/dns://example.com":
+			fmt.Fprint(w, `{"id": "dns%3A%2F%2Fexample.com", "site": {"type": "INET_DOMAIN", "identifier": "example.com"}, "owners": ["admin@example.com"]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "Resource Not Found"}}`)
+		}
+	})
+
+	d := resourceDomainVerification().TestResourceData()
+	d.Set("domain_name", "example.com")
+	d.Set("verification_method", "DNS_TXT")
+
+	if diags := resourceDomainVerificationCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "dns%3A%2F%2Fexample.com" {
+		t.Errorf("unexpected ID %s", d.Id())
+	}
+	if d.Get("token") != "google-site-verification=abc" {
+		t.Errorf("unexpected token %v", d.Get("token"))
+	}
+	if d.Get("owners.0") != "admin@example.com" {
+		t.Errorf("unexpected owners %v", d.Get("owners"))
+	}
+	if inserts != 2 {
+		t.Errorf("expected the verification to be retried once, got %d inserts: %v", inserts, requests())
+	}
+}
+
+func TestResourceDomainVerificationCreate_badRequest(t *testing.T) {
+	inserts := 0
+
+	client, _ := newFakeApiServer(t, "/siteVerification/v1", func(w http.ResponseWriter, r *http.Request, path string) {
+		switch {
+		case r.Method == http.MethodPost && path == "/token":
+			fmt.Fprint(w, `{"method": "DNS_TXT", "token": "google-site-verification=abc"}`)
+		case r.Method == http.MethodPost && path == "/webResource":
+			inserts++
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": {"code": 400, "message": "Invalid value for site identifier."}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "Resource Not Found"}}`)
+		}
+	})
+
+	d := resourceDomainVerification().TestResourceData()
+	d.Set("domain_name", "example.com")
+	d.Set("verification_method", "DNS_TXT")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	diags := resourceDomainVerificationCreate(ctx, d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "Invalid value for site identifier") {
+		t.Fatalf("expected the bad request error, got %v", diags)
+	}
+	if inserts != 1 {
+		t.Errorf("expected the bad request to not be retried, got %d inserts", inserts)
+	}
+}
+
+func TestAccResourceDomainVerification_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	// the primary domain of the customer is verified already, so verifying it again succeeds at once
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "googleworkspace_domain_verification" "test" {
+  domain_name = "%s"
+}
+`, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("googleworkspace_domain_verification.test", "token"),
+					resource.TestCheckResourceAttrSet("googleworkspace_domain_verification.test", "owners.0"),
+				),
+			},
+			{
+				ResourceName:            "googleworkspace_domain_verification.test",
+				ImportState:             true,
+				ImportStateId:           domainName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+		},
+	})
+}
@@ -0,0 +1,239 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/api/gmail/v1"
+)
+
+const gmailDelegateIdSeparator = "/"
+
+func resourceGmailDelegate() *schema.Resource {
+	return &schema.Resource{
+		Description: "Gmail Delegate resource grants a delegate access to the mailbox of a user. The delegate is " +
+			"`pending` until the delegation is accepted, which is waited for by default. Rejected and expired " +
+			"delegations are created again. Please ensure the Gmail API is enabled for your workspace and that " +
+			"both users have a Gmail license. Gmail Delegate resides under the " +
+			"`https://www.googleapis.com/auth/gmail.settings.sharing` client scope.",
+
+		CreateContext: resourceGmailDelegateCreate,
+		ReadContext:   resourceGmailDelegateRead,
+		UpdateContext: resourceGmailDelegateUpdate,
+		DeleteContext: resourceGmailDelegateDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGmailDelegateImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"primary_email": {
+				Description: "The primary email address of the user whose mailbox is delegated.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"delegate_email": {
+				Description: "The email address of the delegate.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"wait_for_acceptance": {
+				Description: "If `true`, creating the delegate waits until the delegation is accepted. " +
+					"Otherwise the delegate may be `pending` after the apply.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"verification_status": {
+				Description: "Whether the delegation is `accepted` or `pending`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceGmailDelegateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	primaryEmail := d.Get("primary_email").(string)
+	gmailService, diags := client.NewGmailService(ctx, primaryEmail)
+	if diags.HasError() {
+		return diags
+	}
+
+	delegatesService, diags := GetGmailDelegatesService(gmailService)
+	if diags.HasError() {
+		return diags
+	}
+
+	delegateEmail := d.Get("delegate_email").(string)
+	log.Printf("[DEBUG] Creating Gmail Delegate %q", primaryEmail+gmailDelegateIdSeparator+delegateEmail)
+
+	if err := createGmailDelegate(delegatesService, delegateEmail); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(primaryEmail + gmailDelegateIdSeparator + delegateEmail)
+
+	if d.Get("wait_for_acceptance").(bool) {
+		if err := waitForGmailDelegatesAccepted(ctx, delegatesService, []string{delegateEmail}, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[DEBUG] Finished creating Gmail Delegate %q", d.Id())
+
+	return resourceGmailDelegateRead(ctx, d, meta)
+}
+
+func resourceGmailDelegateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	primaryEmail := d.Get("primary_email").(string)
+	gmailService, diags := client.NewGmailService(ctx, primaryEmail)
+	if diags.HasError() {
+		return diags
+	}
+
+	delegatesService, diags := GetGmailDelegatesService(gmailService)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Getting Gmail Delegate %q", d.Id())
+
+	delegate, err := delegatesService.Get("me", d.Get("delegate_email").(string)).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	if isGmailDelegateLapsed(delegate) {
+		log.Printf("[WARN] Gmail Delegate %q is %s, removing from state", d.Id(), delegate.VerificationStatus)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("verification_status", delegate.VerificationStatus)
+
+	log.Printf("[DEBUG] Finished getting Gmail Delegate %q", d.Id())
+
+	return nil
+}
+
+func resourceGmailDelegateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// only wait_for_acceptance can change, which only applies when creating
+	return resourceGmailDelegateRead(ctx, d, meta)
+}
+
+func resourceGmailDelegateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	primaryEmail := d.Get("primary_email").(string)
+	gmailService, diags := client.NewGmailService(ctx, primaryEmail)
+	if diags.HasError() {
+		return diags
+	}
+
+	delegatesService, diags := GetGmailDelegatesService(gmailService)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Deleting Gmail Delegate %q", d.Id())
+
+	err := delegatesService.Delete("me", d.Get("delegate_email").(string)).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	log.Printf("[DEBUG] Finished deleting Gmail Delegate %q", d.Id())
+
+	return nil
+}
+
+func resourceGmailDelegateImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.Split(d.Id(), gmailDelegateIdSeparator)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%q), expected primary-email%sdelegate-email", d.Id(), gmailDelegateIdSeparator)
+	}
+	d.Set("primary_email", idParts[0])
+	d.Set("delegate_email", idParts[1])
+
+	// wait_for_acceptance is not returned in the response, so default it on import
+	d.Set("wait_for_acceptance", true)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// isGmailDelegateLapsed returns whether the delegation was rejected or has expired, so that it has to be
+// created again.
+func isGmailDelegateLapsed(delegate *gmail.Delegate) bool {
+	return delegate.VerificationStatus == "rejected" || delegate.VerificationStatus == "expired"
+}
+
+// createGmailDelegate creates the delegate, replacing a delegation that was rejected or has expired.
+func createGmailDelegate(delegatesService *gmail.UsersSettingsDelegatesService, delegateEmail string) error {
+	_, err := delegatesService.Create("me", &gmail.Delegate{DelegateEmail: delegateEmail}).Do()
+	if err == nil || !isApiErrorWithCode(err, 409) {
+		return err
+	}
+
+	existing, getErr := delegatesService.Get("me", delegateEmail).Do()
+	if getErr != nil || !isGmailDelegateLapsed(existing) {
+		return err
+	}
+
+	log.Printf("[DEBUG] Replacing %s Gmail Delegate %q", existing.VerificationStatus, delegateEmail)
+	if err := delegatesService.Delete("me", delegateEmail).Do(); err != nil {
+		return err
+	}
+
+	_, err = delegatesService.Create("me", &gmail.Delegate{DelegateEmail: delegateEmail}).Do()
+	return err
+}
+
+// waitForGmailDelegatesAccepted polls the delegates until all of them are accepted. A delegation that
+// is rejected or expires fails the wait.
+func waitForGmailDelegatesAccepted(ctx context.Context, delegatesService *gmail.UsersSettingsDelegatesService, delegateEmails []string, timeout time.Duration) error {
+	return retryTimeDuration(ctx, timeout, func() error {
+		for _, email := range delegateEmails {
+			delegate, err := delegatesService.Get("me", email).Do()
+			if isNotFound(err) {
+				return fmt.Errorf("timed out while waiting for the delegate %s to be created", email)
+			}
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case delegate.VerificationStatus == "accepted":
+				continue
+			case isGmailDelegateLapsed(delegate):
+				return fmt.Errorf("the delegation to %s is %s", email, delegate.VerificationStatus)
+			default:
+				return fmt.Errorf("timed out while waiting for the delegation to %s to be accepted, it is %s", email, delegate.VerificationStatus)
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,202 @@
+package googleworkspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"google.golang.org/api/gmail/v1"
+)
+
+// newFakeGmailDelegatesServer returns a delegates service for a fake Gmail API that serves the given
+// delegates by email address, and a func returning the requests received. Created delegates are
+// pending until they have been read the given number of times.
+func newFakeGmailDelegatesServer(t *testing.T, delegates map[string]string, readsUntilAccepted int) (*gmail.UsersSettingsDelegatesService, func() []string) {
+	reads := map[string]int{}
+
+	client, requests := newFakeApiServer(t, "/gmail/v1/users/me/settings", func(w http.ResponseWriter, r *http.Request, path string) {
+		email := strings.TrimPrefix(path, "/delegates/")
+
+		switch {
+		case r.Method == http.MethodGet && path == "/delegates":
+			resp := &gmail.ListDelegatesResponse{}
+			for email, status := range delegates {
+				resp.Delegates = append(resp.Delegates, &gmail.Delegate{DelegateEmail: email, VerificationStatus: status})
+			}
+			json.NewEncoder(w).Encode(resp)
+		case r.Method == http.MethodPost && path == "/delegates":
+			var delegate gmail.Delegate
+			json.NewDecoder(r.Body).Decode(&delegate)
+			if _, ok := delegates[delegate.DelegateEmail]; ok {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprint(w, `{"error": {"code": 409, "message": "Delegate already exists."}}`)
+				return
+			}
+			delegates[delegate.DelegateEmail] = "pending"
+			delegate.VerificationStatus = "pending"
+			json.NewEncoder(w).Encode(delegate)
+		case r.Method == http.MethodGet && delegates[email] != "":
+			reads[email]++
+			if delegates[email] == "pending" && reads[email] >= readsUntilAccepted {
+				delegates[email] = "accepted"
+			}
+			json.NewEncoder(w).Encode(&gmail.Delegate{DelegateEmail: email, VerificationStatus: delegates[email]})
+		case r.Method == http.MethodDelete && delegates[email] != "":
+			delete(delegates, email)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "Not Found"}}`)
+		}
+	})
+
+	return newFakeGmailService(t, client).Users.Settings.Delegates, requests
+}
+
+func TestResourceGmailDelegateImport(t *testing.T) {
+	t.Parallel()
+
+	for id, want := range map[string][]string{
+		"jane@example.com/john@example.com": {"jane@example.com", "john@example.com"},
+		"jane@example.com":                  nil,
+		"jane@example.com/":                 nil,
+		"a/b/c":                             nil,
+	} {
+		d := resourceGmailDelegate().TestResourceData()
+		d.SetId(id)
+
+		_, err := resourceGmailDelegateImport(context.Background(), d, nil)
+		if want == nil {
+			if err == nil {
+				t.Errorf("%s: expected an error", id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", id, err)
+		}
+		if d.Get("primary_email") != want[0] || d.Get("delegate_email") != want[1] || d.Get("wait_for_acceptance") != true {
+			t.Errorf("%s: unexpected import %v, %v, %v", id, d.Get("primary_email"), d.Get("delegate_email"), d.Get("wait_for_acceptance"))
+		}
+	}
+}
+
+func TestCreateGmailDelegate(t *testing.T) {
+	t.Parallel()
+
+	delegates := map[string]string{
+		"expired@example.com": "expired",
+		"pending@example.com": "pending",
+	}
+	delegatesService, requests := newFakeGmailDelegatesServer(t, delegates, 1)
+
+	if err := createGmailDelegate(delegatesService, "john@example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := createGmailDelegate(delegatesService, "expired@example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := createGmailDelegate(delegatesService, "pending@example.com"); !isApiErrorWithCode(err, 409) {
+		t.Errorf("expected a conflict for the pending delegate, got %v", err)
+	}
+
+	expected := []string{
+		`POST /delegates {"delegateEmail":"john@example.com"}`,
+		`POST /delegates {"delegateEmail":"expired@example.com"}`,
+		"GET /delegates/expired@example.com",
+		"DELETE /delegates/expired@example.com",
+		`POST /delegates {"delegateEmail":"expired@example.com"}`,
+		`POST /delegates {"delegateEmail":"pending@example.com"}`,
+		"GET /delegates/pending@example.com",
+	}
+	if actual := requests(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("requests not equal\n\nactual %v\n\nexpected %v", actual, expected)
+	}
+}
+
+func TestWaitForGmailDelegatesAccepted(t *testing.T) {
+	t.Parallel()
+
+	delegatesService, _ := newFakeGmailDelegatesServer(t, map[string]string{"john@example.com": "pending"}, 2)
+
+	if err := waitForGmailDelegatesAccepted(context.Background(), delegatesService, []string{"john@example.com"}, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	delegatesService, _ = newFakeGmailDelegatesServer(t, map[string]string{"john@example.com": "rejected"}, 1)
+
+	err := waitForGmailDelegatesAccepted(context.Background(), delegatesService, []string{"john@example.com"}, time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "rejected") {
+		t.Errorf("expected the rejected delegation to fail the wait, got %v", err)
+	}
+}
+
+func TestAccResourceGmailDelegate_basic(t *testing.T) {
+	gmailUser := os.Getenv("GOOGLEWORKSPACE_TEST_GMAIL_USER")
+
+	if gmailUser == "" {
+		t.Skip("GOOGLEWORKSPACE_TEST_GMAIL_USER needs to be set to run this test")
+	}
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	data := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"password":   acctest.RandString(10),
+		"gmailUser":  gmailUser,
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGmailDelegate_basic(data),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_gmail_delegate.test", "verification_status", "accepted"),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_gmail_delegate.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccGmailDelegate_basic(data map[string]interface{}) string {
+	return Nprintf(`
+data "googleworkspace_user" "test" {
+  primary_email = "%{gmailUser}"
+}
+
+resource "googleworkspace_user" "delegate" {
+  primary_email = "%{userEmail}@%{domainName}"
+  password = "%{password}"
+
+  name {
+    family_name = "Scott"
+    given_name = "Michael"
+  }
+}
+
+resource "googleworkspace_gmail_delegate" "test" {
+  primary_email  = data.googleworkspace_user.test.primary_email
+  delegate_email = googleworkspace_user.delegate.primary_email
+}
+`, data)
+}
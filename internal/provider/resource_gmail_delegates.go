@@ -0,0 +1,262 @@
+package googleworkspace
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/api/gmail/v1"
+)
+
+func resourceGmailDelegates() *schema.Resource {
+	return &schema.Resource{
+		Description: "Gmail Delegates resource authoritatively manages the delegates of the mailbox of a user: " +
+			"delegates that aren't configured are removed. Delegates are `pending` until the delegation is " +
+			"accepted, which is waited for by default. Rejected and expired delegations are created again. " +
+			"Please ensure the Gmail API is enabled for your workspace and that the users have a Gmail license. " +
+			"Gmail Delegates resides under the `https://www.googleapis.com/auth/gmail.settings.sharing` client scope.",
+
+		CreateContext: resourceGmailDelegatesCreate,
+		ReadContext:   resourceGmailDelegatesRead,
+		UpdateContext: resourceGmailDelegatesUpdate,
+		DeleteContext: resourceGmailDelegatesDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGmailDelegatesImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"primary_email": {
+				Description: "The primary email address of the user whose mailbox is delegated.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"delegates": {
+				Description: "The email addresses of all delegates of the mailbox.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"wait_for_acceptance": {
+				Description: "If `true`, adding delegates waits until the delegations are accepted. " +
+					"Otherwise delegates may be `pending` after the apply.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"verification_statuses": {
+				Description: "Whether the delegation is `accepted` or `pending`, by delegate email address.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceGmailDelegatesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	primaryEmail := d.Get("primary_email").(string)
+	log.Printf("[DEBUG] Creating Gmail Delegates %q", primaryEmail)
+
+	if diags := applyGmailDelegates(ctx, d, meta.(*apiClient), d.Timeout(schema.TimeoutCreate)); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(primaryEmail)
+	log.Printf("[DEBUG] Finished creating Gmail Delegates %q", d.Id())
+
+	return resourceGmailDelegatesRead(ctx, d, meta)
+}
+
+func resourceGmailDelegatesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	gmailService, diags := client.NewGmailService(ctx, d.Get("primary_email").(string))
+	if diags.HasError() {
+		return diags
+	}
+
+	delegatesService, diags := GetGmailDelegatesService(gmailService)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Getting Gmail Delegates %q", d.Id())
+
+	current, err := listGmailDelegates(delegatesService)
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	configured := listOfInterfacestoStrings(d.Get("delegates").(*schema.Set).List())
+
+	var emails []string
+	statuses := map[string]interface{}{}
+	for _, delegate := range current {
+		emails = append(emails, delegate.DelegateEmail)
+		statuses[delegate.DelegateEmail] = delegate.VerificationStatus
+	}
+
+	if err := d.Set("delegates", matchConfiguredMembers(configured, emails)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("verification_statuses", statuses)
+
+	log.Printf("[DEBUG] Finished getting Gmail Delegates %q", d.Id())
+
+	return nil
+}
+
+func resourceGmailDelegatesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Updating Gmail Delegates %q", d.Id())
+
+	if d.HasChange("delegates") {
+		if diags := applyGmailDelegates(ctx, d, meta.(*apiClient), d.Timeout(schema.TimeoutUpdate)); diags.HasError() {
+			return diags
+		}
+	}
+
+	log.Printf("[DEBUG] Finished updating Gmail Delegates %q", d.Id())
+
+	return resourceGmailDelegatesRead(ctx, d, meta)
+}
+
+func resourceGmailDelegatesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	gmailService, diags := client.NewGmailService(ctx, d.Get("primary_email").(string))
+	if diags.HasError() {
+		return diags
+	}
+
+	delegatesService, diags := GetGmailDelegatesService(gmailService)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Deleting Gmail Delegates %q", d.Id())
+
+	for _, email := range listOfInterfacestoStrings(d.Get("delegates").(*schema.Set).List()) {
+		err := delegatesService.Delete("me", email).Do()
+		if err != nil && !isNotFound(err) {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[DEBUG] Finished deleting Gmail Delegates %q", d.Id())
+
+	return nil
+}
+
+func resourceGmailDelegatesImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("primary_email", d.Id())
+
+	// wait_for_acceptance is not returned in the response, so default it on import
+	d.Set("wait_for_acceptance", true)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func applyGmailDelegates(ctx context.Context, d *schema.ResourceData, client *apiClient, timeout time.Duration) diag.Diagnostics {
+	gmailService, diags := client.NewGmailService(ctx, d.Get("primary_email").(string))
+	if diags.HasError() {
+		return diags
+	}
+
+	delegatesService, diags := GetGmailDelegatesService(gmailService)
+	if diags.HasError() {
+		return diags
+	}
+
+	desired := listOfInterfacestoStrings(d.Get("delegates").(*schema.Set).List())
+
+	if err := syncGmailDelegates(delegatesService, desired); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("wait_for_acceptance").(bool) {
+		if err := waitForGmailDelegatesAccepted(ctx, delegatesService, desired, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// syncGmailDelegates creates the desired delegates that are missing, and deletes the ones that aren't desired.
+func syncGmailDelegates(delegatesService *gmail.UsersSettingsDelegatesService, desired []string) error {
+	current, err := listGmailDelegates(delegatesService)
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]bool{}
+	for _, delegate := range current {
+		existing[strings.ToLower(delegate.DelegateEmail)] = true
+	}
+
+	wanted := map[string]bool{}
+	for _, email := range desired {
+		wanted[strings.ToLower(email)] = true
+
+		if existing[strings.ToLower(email)] {
+			continue
+		}
+
+		log.Printf("[DEBUG] Creating Gmail Delegate %q", email)
+		if err := createGmailDelegate(delegatesService, email); err != nil {
+			return err
+		}
+	}
+
+	for _, delegate := range current {
+		if wanted[strings.ToLower(delegate.DelegateEmail)] {
+			continue
+		}
+
+		log.Printf("[DEBUG] Deleting Gmail Delegate %q", delegate.DelegateEmail)
+		if err := delegatesService.Delete("me", delegate.DelegateEmail).Do(); err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listGmailDelegates returns the delegates of the mailbox, except the delegations that were rejected or
+// have expired, which are created again.
+func listGmailDelegates(delegatesService *gmail.UsersSettingsDelegatesService) ([]*gmail.Delegate, error) {
+	resp, err := delegatesService.List("me").Do()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*gmail.Delegate
+	for _, delegate := range resp.Delegates {
+		if !isGmailDelegateLapsed(delegate) {
+			result = append(result, delegate)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,151 @@
+package googleworkspace
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestSyncGmailDelegates(t *testing.T) {
+	t.Parallel()
+
+	delegates := map[string]string{
+		"keep@example.com":    "accepted",
+		"remove@example.com":  "pending",
+		"expired@example.com": "expired",
+	}
+	delegatesService, requests := newFakeGmailDelegatesServer(t, delegates, 1)
+
+	if err := syncGmailDelegates(delegatesService, []string{"Keep@example.com", "expired@example.com", "new@example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var actual []string
+	for email, status := range delegates {
+		actual = append(actual, email+" "+status)
+	}
+	sort.Strings(actual)
+
+	expected := []string{
+		"expired@example.com pending",
+		"keep@example.com accepted",
+		"new@example.com pending",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("delegates not equal\n\nactual %v\n\nexpected %v", actual, expected)
+	}
+
+	for _, r := range requests() {
+		if r == "DELETE /delegates/keep@example.com" {
+			t.Errorf("unexpected request %s", r)
+		}
+	}
+}
+
+func TestListGmailDelegates(t *testing.T) {
+	t.Parallel()
+
+	delegatesService, _ := newFakeGmailDelegatesServer(t, map[string]string{
+		"accepted@example.com": "accepted",
+		"rejected@example.com": "rejected",
+	}, 1)
+
+	delegates, err := listGmailDelegates(delegatesService)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(delegates) != 1 || delegates[0].DelegateEmail != "accepted@example.com" {
+		t.Errorf("expected only the accepted delegate, got %v", delegates)
+	}
+}
+
+func TestAccResourceGmailDelegates_basic(t *testing.T) {
+	gmailUser := os.Getenv("GOOGLEWORKSPACE_TEST_GMAIL_USER")
+
+	if gmailUser == "" {
+		t.Skip("GOOGLEWORKSPACE_TEST_GMAIL_USER needs to be set to run this test")
+	}
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	data := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"password":   acctest.RandString(10),
+		"gmailUser":  gmailUser,
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGmailDelegates(data, "[googleworkspace_user.first.primary_email]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_gmail_delegates.test", "delegates.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_gmail_delegates.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccGmailDelegates(data, "[googleworkspace_user.second.primary_email]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_gmail_delegates.test", "delegates.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("googleworkspace_gmail_delegates.test", "delegates.*", "googleworkspace_user.second", "primary_email"),
+				),
+			},
+			{
+				Config: testAccGmailDelegates(data, "[]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_gmail_delegates.test", "delegates.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGmailDelegates(data map[string]interface{}, delegates string) string {
+	data["delegates"] = delegates
+	return Nprintf(`
+data "googleworkspace_user" "test" {
+  primary_email = "%{gmailUser}"
+}
+
+resource "googleworkspace_user" "first" {
+  primary_email = "%{userEmail}-1@%{domainName}"
+  password = "%{password}"
+
+  name {
+    family_name = "Scott"
+    given_name = "Michael"
+  }
+}
+
+resource "googleworkspace_user" "second" {
+  primary_email = "%{userEmail}-2@%{domainName}"
+  password = "%{password}"
+
+  name {
+    family_name = "Halpert"
+    given_name = "Jim"
+  }
+}
+
+resource "googleworkspace_gmail_delegates" "test" {
+  primary_email = data.googleworkspace_user.test.primary_email
+  delegates     = %{delegates}
+}
+`, data)
+}
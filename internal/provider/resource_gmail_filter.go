@@ -0,0 +1,420 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/api/gmail/v1"
+)
+
+const gmailFilterIdSeparator = "/"
+
+func resourceGmailFilter() *schema.Resource {
+	return &schema.Resource{
+		Description: "Gmail Filter resource manages a filter of the mailbox of a user. Filters can't be updated, " +
+			"so any change deletes the filter and creates it again. Creating a filter with the same criteria and " +
+			"action as an existing filter of the mailbox fails, the existing filter can be imported instead. Please ensure the Gmail API is enabled for " +
+			"your workspace and that the user has a Gmail license. Gmail Filter resides under the " +
+			"`https://www.googleapis.com/auth/gmail.settings.basic` client scope.",
+
+		CreateContext: resourceGmailFilterCreate,
+		ReadContext:   resourceGmailFilterRead,
+		DeleteContext: resourceGmailFilterDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGmailFilterImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"primary_email": {
+				Description: "The primary email address of the user whose mailbox is filtered.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"filter_id": {
+				Description: "The ID of the filter.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"criteria": {
+				Description: "The message matching criteria of the filter.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Required:    true,
+				ForceNew:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"from": {
+							Description: "The sender's display name or email address.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"to": {
+							Description: "The recipient's display name or email address. Includes recipients in the " +
+								"'To', 'Cc' and 'Bcc' header fields.",
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"subject": {
+							Description: "Case-insensitive phrase found in the message's subject.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"query": {
+							Description: "Only return messages matching the specified query, in the same format as " +
+								"the Gmail search box.",
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"negated_query": {
+							Description: "Only return messages not matching the specified query, in the same format " +
+								"as the Gmail search box.",
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"has_attachment": {
+							Description: "Whether the message has any attachment.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"exclude_chats": {
+							Description: "Whether the response should exclude chats.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"size": {
+							Description: "The size of the entire RFC822 message in bytes, including all headers and " +
+								"attachments.",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ForceNew:     true,
+							RequiredWith: []string{"criteria.0.size_comparison"},
+						},
+						"size_comparison": {
+							Description: "How the message size in bytes should be in relation to the size field. " +
+								"Acceptable values are `larger` and `smaller`.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ForceNew:         true,
+							RequiredWith:     []string{"criteria.0.size"},
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"larger", "smaller"}, false)),
+						},
+					},
+				},
+			},
+			"action": {
+				Description: "The action that the filter performs on matching messages.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Required:    true,
+				ForceNew:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"add_label_ids": {
+							Description: "The IDs of the labels to add to the message, such as `STARRED`, " +
+								"`IMPORTANT` or the ID of a user label.",
+							Type:     schema.TypeSet,
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"remove_label_ids": {
+							Description: "The IDs of the labels to remove from the message, such as `INBOX` to " +
+								"archive it or `UNREAD` to mark it as read.",
+							Type:     schema.TypeSet,
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"forward": {
+							Description: "The email address that the message should be forwarded to. The address " +
+								"must be a verified forwarding address of the user.",
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceGmailFilterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	primaryEmail := d.Get("primary_email").(string)
+	gmailService, diags := client.NewGmailService(ctx, primaryEmail)
+	if diags.HasError() {
+		return diags
+	}
+
+	filtersService, diags := GetGmailFiltersService(gmailService)
+	if diags.HasError() {
+		return diags
+	}
+
+	filter := expandGmailFilter(d)
+	log.Printf("[DEBUG] Creating Gmail Filter for %q", primaryEmail)
+
+	existing, err := findGmailFilter(filtersService, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if existing != nil {
+		return diag.Errorf("a Gmail Filter with the same criteria and action already exists for %s, "+
+			"use `terraform import` with the ID %s%s%s to manage it", primaryEmail, primaryEmail, gmailFilterIdSeparator, existing.Id)
+	}
+
+	filter, err = filtersService.Create("me", filter).Do()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(primaryEmail + gmailFilterIdSeparator + filter.Id)
+	d.Set("filter_id", filter.Id)
+
+	log.Printf("[DEBUG] Finished creating Gmail Filter %q", d.Id())
+
+	return resourceGmailFilterRead(ctx, d, meta)
+}
+
+func resourceGmailFilterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	primaryEmail := d.Get("primary_email").(string)
+	gmailService, diags := client.NewGmailService(ctx, primaryEmail)
+	if diags.HasError() {
+		return diags
+	}
+
+	filtersService, diags := GetGmailFiltersService(gmailService)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Getting Gmail Filter %q", d.Id())
+
+	filter, err := filtersService.Get("me", d.Get("filter_id").(string)).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	// Gmail may return the criteria and action differently than configured, such as reordered
+	// labels or changed case, so the configured filter is kept when it's equivalent.
+	if !gmailFiltersEqual(expandGmailFilter(d), filter) {
+		if err := d.Set("criteria", flattenGmailFilterCriteria(filter.Criteria)); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("action", flattenGmailFilterAction(filter.Action)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[DEBUG] Finished getting Gmail Filter %q", d.Id())
+
+	return nil
+}
+
+func resourceGmailFilterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	primaryEmail := d.Get("primary_email").(string)
+	gmailService, diags := client.NewGmailService(ctx, primaryEmail)
+	if diags.HasError() {
+		return diags
+	}
+
+	filtersService, diags := GetGmailFiltersService(gmailService)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Deleting Gmail Filter %q", d.Id())
+
+	err := filtersService.Delete("me", d.Get("filter_id").(string)).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	log.Printf("[DEBUG] Finished deleting Gmail Filter %q", d.Id())
+
+	return nil
+}
+
+func resourceGmailFilterImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.Split(d.Id(), gmailFilterIdSeparator)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%q), expected primary-email%sfilter-id", d.Id(), gmailFilterIdSeparator)
+	}
+	d.Set("primary_email", idParts[0])
+	d.Set("filter_id", idParts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandGmailFilter(d *schema.ResourceData) *gmail.Filter {
+	filter := &gmail.Filter{
+		Criteria: &gmail.FilterCriteria{},
+		Action:   &gmail.FilterAction{},
+	}
+
+	if criteria := d.Get("criteria").([]interface{}); len(criteria) > 0 && criteria[0] != nil {
+		values := criteria[0].(map[string]interface{})
+		filter.Criteria = &gmail.FilterCriteria{
+			From:           values["from"].(string),
+			To:             values["to"].(string),
+			Subject:        values["subject"].(string),
+			Query:          values["query"].(string),
+			NegatedQuery:   values["negated_query"].(string),
+			HasAttachment:  values["has_attachment"].(bool),
+			ExcludeChats:   values["exclude_chats"].(bool),
+			Size:           int64(values["size"].(int)),
+			SizeComparison: values["size_comparison"].(string),
+		}
+	}
+
+	if action := d.Get("action").([]interface{}); len(action) > 0 && action[0] != nil {
+		values := action[0].(map[string]interface{})
+		filter.Action = &gmail.FilterAction{
+			AddLabelIds:    listOfInterfacestoStrings(values["add_label_ids"].(*schema.Set).List()),
+			RemoveLabelIds: listOfInterfacestoStrings(values["remove_label_ids"].(*schema.Set).List()),
+			Forward:        values["forward"].(string),
+		}
+	}
+
+	return filter
+}
+
+func flattenGmailFilterCriteria(criteria *gmail.FilterCriteria) []interface{} {
+	if criteria == nil {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"from":            criteria.From,
+			"to":              criteria.To,
+			"subject":         criteria.Subject,
+			"query":           criteria.Query,
+			"negated_query":   criteria.NegatedQuery,
+			"has_attachment":  criteria.HasAttachment,
+			"exclude_chats":   criteria.ExcludeChats,
+			"size":            int(criteria.Size),
+			"size_comparison": criteria.SizeComparison,
+		},
+	}
+}
+
+func flattenGmailFilterAction(action *gmail.FilterAction) []interface{} {
+	if action == nil {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"add_label_ids":    action.AddLabelIds,
+			"remove_label_ids": action.RemoveLabelIds,
+			"forward":          action.Forward,
+		},
+	}
+}
+
+// normalizeGmailFilter returns the criteria and action of the filter in a canonical form: the sender,
+// recipient and subject are matched case-insensitively, whitespace is collapsed, label order doesn't
+// matter and the size comparison only applies together with a size. The queries are not case-folded,
+// as the case of their operators is significant, such as `OR`.
+func normalizeGmailFilter(filter *gmail.Filter) (gmail.FilterCriteria, gmail.FilterAction) {
+	normalizeSpace := func(s string) string {
+		return strings.Join(strings.Fields(s), " ")
+	}
+	normalizeText := func(s string) string {
+		return normalizeSpace(strings.ToLower(s))
+	}
+	normalizeLabels := func(labels []string) []string {
+		result := append([]string{}, labels...)
+		sort.Strings(result)
+		return result
+	}
+
+	var criteria gmail.FilterCriteria
+	if filter.Criteria != nil {
+		criteria = gmail.FilterCriteria{
+			From:          normalizeText(filter.Criteria.From),
+			To:            normalizeText(filter.Criteria.To),
+			Subject:       normalizeText(filter.Criteria.Subject),
+			Query:         normalizeSpace(filter.Criteria.Query),
+			NegatedQuery:  normalizeSpace(filter.Criteria.NegatedQuery),
+			HasAttachment: filter.Criteria.HasAttachment,
+			ExcludeChats:  filter.Criteria.ExcludeChats,
+			Size:          filter.Criteria.Size,
+		}
+		if criteria.Size != 0 {
+			criteria.SizeComparison = strings.ToLower(filter.Criteria.SizeComparison)
+		}
+	}
+
+	action := gmail.FilterAction{
+		AddLabelIds:    []string{},
+		RemoveLabelIds: []string{},
+	}
+	if filter.Action != nil {
+		action = gmail.FilterAction{
+			AddLabelIds:    normalizeLabels(filter.Action.AddLabelIds),
+			RemoveLabelIds: normalizeLabels(filter.Action.RemoveLabelIds),
+			Forward:        strings.ToLower(strings.TrimSpace(filter.Action.Forward)),
+		}
+	}
+
+	return criteria, action
+}
+
+// gmailFiltersEqual returns whether the filters have equivalent criteria and actions.
+func gmailFiltersEqual(a, b *gmail.Filter) bool {
+	criteriaA, actionA := normalizeGmailFilter(a)
+	criteriaB, actionB := normalizeGmailFilter(b)
+
+	return reflect.DeepEqual(criteriaA, criteriaB) && reflect.DeepEqual(actionA, actionB)
+}
+
+// findGmailFilter returns the existing filter of the mailbox equivalent to the given filter, if any.
+func findGmailFilter(filtersService *gmail.UsersSettingsFiltersService, filter *gmail.Filter) (*gmail.Filter, error) {
+	resp, err := filtersService.List("me").Do()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, existing := range resp.Filter {
+		if gmailFiltersEqual(filter, existing) {
+			return existing, nil
+		}
+	}
+
+	return nil, nil
+}
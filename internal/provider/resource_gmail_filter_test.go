@@ -0,0 +1,219 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"google.golang.org/api/gmail/v1"
+)
+
+func TestGmailFiltersEqual(t *testing.T) {
+	t.Parallel()
+
+	configured := &gmail.Filter{
+		Criteria: &gmail.FilterCriteria{From: "Alerts@Example.com", Query: "is:unread  OR label:ops", SizeComparison: "larger"},
+		Action:   &gmail.FilterAction{AddLabelIds: []string{"STARRED", "Label_1"}, Forward: "ops@example.com"},
+	}
+
+	cases := map[string]struct {
+		filter *gmail.Filter
+		want   bool
+	}{
+		"normalized": {
+			filter: &gmail.Filter{
+				Id:       "abc",
+				Criteria: &gmail.FilterCriteria{From: "alerts@example.com", Query: "is:unread OR label:ops"},
+				Action:   &gmail.FilterAction{AddLabelIds: []string{"Label_1", "STARRED"}, Forward: "OPS@example.com"},
+			},
+			want: true,
+		},
+		"different criteria": {
+			filter: &gmail.Filter{
+				Criteria: &gmail.FilterCriteria{From: "alerts@example.com", Query: "is:read OR label:ops"},
+				Action:   &gmail.FilterAction{AddLabelIds: []string{"Label_1", "STARRED"}, Forward: "ops@example.com"},
+			},
+			want: false,
+		},
+		"different query case": {
+			filter: &gmail.Filter{
+				Criteria: &gmail.FilterCriteria{From: "alerts@example.com", Query: "is:unread or label:ops"},
+				Action:   &gmail.FilterAction{AddLabelIds: []string{"Label_1", "STARRED"}, Forward: "ops@example.com"},
+			},
+			want: false,
+		},
+		"different labels": {
+			filter: &gmail.Filter{
+				Criteria: &gmail.FilterCriteria{From: "alerts@example.com", Query: "is:unread OR label:ops"},
+				Action:   &gmail.FilterAction{AddLabelIds: []string{"label_1", "STARRED"}, Forward: "ops@example.com"},
+			},
+			want: false,
+		},
+		"no action": {
+			filter: &gmail.Filter{
+				Criteria: &gmail.FilterCriteria{From: "alerts@example.com", Query: "is:unread OR label:ops"},
+			},
+			want: false,
+		},
+	}
+
+	for name, c := range cases {
+		if got := gmailFiltersEqual(configured, c.filter); got != c.want {
+			t.Errorf("%s: expected %t, got %t", name, c.want, got)
+		}
+	}
+}
+
+func TestExpandGmailFilter(t *testing.T) {
+	t.Parallel()
+
+	d := resourceGmailFilter().TestResourceData()
+	d.Set("criteria", []interface{}{map[string]interface{}{"from": "alerts@example.com", "size": 1024, "size_comparison": "larger"}})
+	d.Set("action", []interface{}{map[string]interface{}{"remove_label_ids": []interface{}{"INBOX"}}})
+
+	filter := expandGmailFilter(d)
+
+	if filter.Criteria.From != "alerts@example.com" || filter.Criteria.Size != 1024 || filter.Criteria.SizeComparison != "larger" {
+		t.Errorf("unexpected criteria %+v", filter.Criteria)
+	}
+	if len(filter.Action.RemoveLabelIds) != 1 || filter.Action.RemoveLabelIds[0] != "INBOX" || len(filter.Action.AddLabelIds) != 0 {
+		t.Errorf("unexpected action %+v", filter.Action)
+	}
+
+	// a round trip through the flattened API response is equivalent
+	roundTrip := &gmail.Filter{
+		Criteria: &gmail.FilterCriteria{From: "alerts@example.com", Size: 1024, SizeComparison: "larger"},
+		Action:   &gmail.FilterAction{RemoveLabelIds: []string{"INBOX"}},
+	}
+	d.Set("criteria", flattenGmailFilterCriteria(roundTrip.Criteria))
+	d.Set("action", flattenGmailFilterAction(roundTrip.Action))
+	if !gmailFiltersEqual(expandGmailFilter(d), roundTrip) {
+		t.Errorf("expected the flattened filter to be equal, got %+v", expandGmailFilter(d))
+	}
+}
+
+func TestFindGmailFilter(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newFakeApiServer(t, "/gmail/v1/users/me/settings", func(w http.ResponseWriter, r *http.Request, path string) {
+		if r.Method != http.MethodGet || path != "/filters" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "Not Found"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"filter": [
+			{"id": "one", "criteria": {"from": "a@example.com"}, "action": {"addLabelIds": ["TRASH"]}},
+			{"id": "two", "criteria": {"from": "b@example.com"}, "action": {"addLabelIds": ["TRASH"]}}
+		]}`)
+	})
+	gmailService := newFakeGmailService(t, client)
+
+	filter, err := findGmailFilter(gmailService.Users.Settings.Filters, &gmail.Filter{
+		Criteria: &gmail.FilterCriteria{From: "B@example.com"},
+		Action:   &gmail.FilterAction{AddLabelIds: []string{"TRASH"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filter == nil || filter.Id != "two" {
+		t.Errorf("expected filter two, got %+v", filter)
+	}
+
+	filter, err = findGmailFilter(gmailService.Users.Settings.Filters, &gmail.Filter{
+		Criteria: &gmail.FilterCriteria{From: "c@example.com"},
+		Action:   &gmail.FilterAction{AddLabelIds: []string{"TRASH"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filter != nil {
+		t.Errorf("expected no filter, got %+v", filter)
+	}
+}
+
+func TestResourceGmailFilterImport(t *testing.T) {
+	t.Parallel()
+
+	for id, want := range map[string][]string{
+		"jane@example.com/ANe1Bmj": {"jane@example.com", "ANe1Bmj"},
+		"ANe1Bmj":                  nil,
+	} {
+		d := resourceGmailFilter().TestResourceData()
+		d.SetId(id)
+
+		_, err := resourceGmailFilterImport(context.Background(), d, nil)
+		if want == nil {
+			if err == nil {
+				t.Errorf("%s: expected an error", id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", id, err)
+		}
+		if d.Get("primary_email") != want[0] || d.Get("filter_id") != want[1] {
+			t.Errorf("%s: unexpected import %v, %v", id, d.Get("primary_email"), d.Get("filter_id"))
+		}
+	}
+}
+
+func TestAccResourceGmailFilter_basic(t *testing.T) {
+	gmailUser := os.Getenv("GOOGLEWORKSPACE_TEST_GMAIL_USER")
+
+	if gmailUser == "" {
+		t.Skip("GOOGLEWORKSPACE_TEST_GMAIL_USER needs to be set to run this test")
+	}
+
+	data := map[string]interface{}{
+		"gmailUser": gmailUser,
+		"subject":   fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGmailFilter(data, "STARRED"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("googleworkspace_gmail_filter.test", "filter_id"),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_gmail_filter.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccGmailFilter(data, "IMPORTANT"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("googleworkspace_gmail_filter.test", "action.0.add_label_ids.*", "IMPORTANT"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGmailFilter(data map[string]interface{}, label string) string {
+	data["label"] = label
+	return Nprintf(`
+resource "googleworkspace_gmail_filter" "test" {
+  primary_email = "%{gmailUser}"
+
+  criteria {
+    subject         = "%{subject}"
+    size            = 1048576
+    size_comparison = "larger"
+  }
+
+  action {
+    add_label_ids    = ["%{label}"]
+    remove_label_ids = ["INBOX"]
+  }
+}
+`, data)
+}
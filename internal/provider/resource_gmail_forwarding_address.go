@@ -0,0 +1,154 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/api/gmail/v1"
+)
+
+const gmailForwardingAddressIdSeparator = "/"
+
+func resourceGmailForwardingAddress() *schema.Resource {
+	return &schema.Resource{
+		Description: "Gmail Forwarding Address resource manages an address that the mail of a user can be " +
+			"forwarded to, such as by a `googleworkspace_gmail_filter`. Addresses outside of the domain are " +
+			"`pending` until the owner of the address follows the link of the verification email. Please " +
+			"ensure the Gmail API is enabled for your workspace and that the user has a Gmail license. Gmail " +
+			"Forwarding Address resides under the `https://www.googleapis.com/auth/gmail.settings.sharing` client scope.",
+
+		CreateContext: resourceGmailForwardingAddressCreate,
+		ReadContext:   resourceGmailForwardingAddressRead,
+		DeleteContext: resourceGmailForwardingAddressDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGmailForwardingAddressImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"primary_email": {
+				Description: "The primary email address of the user whose mail is forwarded.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"forwarding_email": {
+				Description: "The email address that mail can be forwarded to.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"verification_status": {
+				Description: "Whether the forwarding address is `accepted` or `pending` verification.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceGmailForwardingAddressCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	primaryEmail := d.Get("primary_email").(string)
+	gmailService, diags := client.NewGmailService(ctx, primaryEmail)
+	if diags.HasError() {
+		return diags
+	}
+
+	forwardingAddressesService, diags := GetGmailForwardingAddressesService(gmailService)
+	if diags.HasError() {
+		return diags
+	}
+
+	forwardingEmail := d.Get("forwarding_email").(string)
+	log.Printf("[DEBUG] Creating Gmail Forwarding Address %q", primaryEmail+gmailForwardingAddressIdSeparator+forwardingEmail)
+
+	_, err := forwardingAddressesService.Create("me", &gmail.ForwardingAddress{
+		ForwardingEmail: forwardingEmail,
+	}).Do()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(primaryEmail + gmailForwardingAddressIdSeparator + forwardingEmail)
+
+	log.Printf("[DEBUG] Finished creating Gmail Forwarding Address %q", d.Id())
+
+	return resourceGmailForwardingAddressRead(ctx, d, meta)
+}
+
+func resourceGmailForwardingAddressRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	primaryEmail := d.Get("primary_email").(string)
+	gmailService, diags := client.NewGmailService(ctx, primaryEmail)
+	if diags.HasError() {
+		return diags
+	}
+
+	forwardingAddressesService, diags := GetGmailForwardingAddressesService(gmailService)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Getting Gmail Forwarding Address %q", d.Id())
+
+	forwardingAddress, err := forwardingAddressesService.Get("me", d.Get("forwarding_email").(string)).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	d.Set("verification_status", forwardingAddress.VerificationStatus)
+
+	log.Printf("[DEBUG] Finished getting Gmail Forwarding Address %q", d.Id())
+
+	return nil
+}
+
+func resourceGmailForwardingAddressDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	primaryEmail := d.Get("primary_email").(string)
+	gmailService, diags := client.NewGmailService(ctx, primaryEmail)
+	if diags.HasError() {
+		return diags
+	}
+
+	forwardingAddressesService, diags := GetGmailForwardingAddressesService(gmailService)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Deleting Gmail Forwarding Address %q", d.Id())
+
+	err := forwardingAddressesService.Delete("me", d.Get("forwarding_email").(string)).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	log.Printf("[DEBUG] Finished deleting Gmail Forwarding Address %q", d.Id())
+
+	return nil
+}
+
+func resourceGmailForwardingAddressImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.Split(d.Id(), gmailForwardingAddressIdSeparator)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%q), expected primary-email%sforwarding-email", d.Id(), gmailForwardingAddressIdSeparator)
+	}
+	d.Set("primary_email", idParts[0])
+	d.Set("forwarding_email", idParts[1])
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,96 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceGmailForwardingAddressImport(t *testing.T) {
+	t.Parallel()
+
+	for id, want := range map[string][]string{
+		"jane@example.com/archive@example.com": {"jane@example.com", "archive@example.com"},
+		"jane@example.com:archive@example.com": nil,
+	} {
+		d := resourceGmailForwardingAddress().TestResourceData()
+		d.SetId(id)
+
+		_, err := resourceGmailForwardingAddressImport(context.Background(), d, nil)
+		if want == nil {
+			if err == nil {
+				t.Errorf("%s: expected an error", id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", id, err)
+		}
+		if d.Get("primary_email") != want[0] || d.Get("forwarding_email") != want[1] {
+			t.Errorf("%s: unexpected import %v, %v", id, d.Get("primary_email"), d.Get("forwarding_email"))
+		}
+	}
+}
+
+func TestAccResourceGmailForwardingAddress_basic(t *testing.T) {
+	gmailUser := os.Getenv("GOOGLEWORKSPACE_TEST_GMAIL_USER")
+
+	if gmailUser == "" {
+		t.Skip("GOOGLEWORKSPACE_TEST_GMAIL_USER needs to be set to run this test")
+	}
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	data := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"password":   acctest.RandString(10),
+		"gmailUser":  gmailUser,
+	}
+
+	// addresses in the same domain don't need to be verified
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGmailForwardingAddress(data),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_gmail_forwarding_address.test", "verification_status", "accepted"),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_gmail_forwarding_address.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccGmailForwardingAddress(data map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_user" "archive" {
+  primary_email = "%{userEmail}@%{domainName}"
+  password = "%{password}"
+
+  name {
+    family_name = "Scott"
+    given_name = "Michael"
+  }
+}
+
+resource "googleworkspace_gmail_forwarding_address" "test" {
+  primary_email    = "%{gmailUser}"
+  forwarding_email = googleworkspace_user.archive.primary_email
+}
+`, data)
+}
@@ -0,0 +1,653 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/api/gmail/v1"
+)
+
+// gmailSettingsBlocks are the settings managed by the gmail settings resource, each in its own block.
+var gmailSettingsBlocks = []string{"vacation", "imap", "pop", "auto_forwarding", "language"}
+
+var gmailDispositions = []string{"leaveInInbox", "archive", "trash", "markRead"}
+
+func resourceGmailSettings() *schema.Resource {
+	return &schema.Resource{
+		Description: "Gmail Settings resource manages the vacation responder, IMAP, POP, auto-forwarding and " +
+			"language settings of a user. Only the configured blocks are managed: settings without a block " +
+			"are left alone. Destroying the resource restores the Google defaults of the managed settings, " +
+			"except for the language, which is left unchanged. Please ensure the Gmail API is enabled for " +
+			"your workspace and that the user has a Gmail license. Gmail Settings resides under the " +
+			"`https://www.googleapis.com/auth/gmail.settings.basic` client scope, and auto-forwarding under the " +
+			"`https://www.googleapis.com/auth/gmail.settings.sharing` client scope.",
+
+		CreateContext: resourceGmailSettingsCreate,
+		ReadContext:   resourceGmailSettingsRead,
+		UpdateContext: resourceGmailSettingsUpdate,
+		DeleteContext: resourceGmailSettingsDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGmailSettingsImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"primary_email": {
+				Description: "The primary email address of the user.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"vacation": {
+				Description: "The vacation responder settings.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enable_auto_reply": {
+							Description: "Whether Gmail automatically replies to messages.",
+							Type:        schema.TypeBool,
+							Required:    true,
+						},
+						"response_subject": {
+							Description: "Optional text to prepend to the subject line in vacation responses. " +
+								"In order to enable auto-replies, either the response subject or the response " +
+								"body must be nonempty.",
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"response_body_plain_text": {
+							Description: "Response body in plain text format. If both `response_body_plain_text` " +
+								"and `response_body_html` are specified, `response_body_html` will be used.",
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"response_body_html": {
+							Description: "Response body in HTML format. Gmail will sanitize the HTML before storing it, " +
+								"the configured HTML is kept if the HTML Gmail saved when it was written has the same text.",
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"saved_response_body_html": {
+							Description: "The response body HTML as sanitized and saved by Gmail.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"restrict_to_contacts": {
+							Description: "Whether responses are only sent to recipients in the user's list of contacts.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+						"restrict_to_domain": {
+							Description: "Whether responses are only sent to recipients in the domain of the user.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+						"start_time": {
+							Description: "The time, in RFC 3339 format, after which auto-replies are sent, such as " +
+								"`2024-07-01T00:00:00Z`. If empty, auto-replies start at once.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+						},
+						"end_time": {
+							Description: "The time, in RFC 3339 format, after which auto-replies are no longer sent. " +
+								"If empty, auto-replies are sent until they are disabled.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+						},
+					},
+				},
+			},
+			"imap": {
+				Description: "The IMAP settings.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Description: "Whether IMAP is enabled for the account.",
+							Type:        schema.TypeBool,
+							Required:    true,
+						},
+						"auto_expunge": {
+							Description: "If this value is true, Gmail will immediately expunge a message when it is " +
+								"marked as deleted in IMAP. Otherwise, Gmail will wait for an update from the client " +
+								"before expunging messages marked as deleted.",
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"expunge_behavior": {
+							Description: "The action that will be executed on a message when it is marked as deleted " +
+								"and expunged from the last visible IMAP folder. Acceptable values are `archive`, " +
+								"`trash` and `deleteForever`.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "archive",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"archive", "trash", "deleteForever"}, false)),
+						},
+						"max_folder_size": {
+							Description: "An optional limit on the number of messages that an IMAP folder may contain. " +
+								"Acceptable values are `0` for no limit, `1000`, `2000`, `5000` and `10000`.",
+							Type:             schema.TypeInt,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntInSlice([]int{0, 1000, 2000, 5000, 10000})),
+						},
+					},
+				},
+			},
+			"pop": {
+				Description: "The POP settings.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"access_window": {
+							Description: "The range of messages which are accessible via POP. Acceptable values are " +
+								"`disabled`, `fromNowOn` and `allMail`.",
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"disabled", "fromNowOn", "allMail"}, false)),
+						},
+						"disposition": {
+							Description: "The action that will be executed on a message after it has been fetched via " +
+								"POP. Acceptable values are `leaveInInbox`, `archive`, `trash` and `markRead`.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "leaveInInbox",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(gmailDispositions, false)),
+						},
+					},
+				},
+			},
+			"auto_forwarding": {
+				Description: "The auto-forwarding settings.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Description: "Whether all incoming mail is automatically forwarded to another address.",
+							Type:        schema.TypeBool,
+							Required:    true,
+						},
+						"email_address": {
+							Description: "Email address to which all incoming messages are forwarded. This email " +
+								"address must be a verified forwarding address of the user, such as a " +
+								"`googleworkspace_gmail_forwarding_address`.",
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"disposition": {
+							Description: "The state that a message should be left in after it has been forwarded. " +
+								"Acceptable values are `leaveInInbox`, `archive`, `trash` and `markRead`.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "leaveInInbox",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(gmailDispositions, false)),
+						},
+					},
+				},
+			},
+			"language": {
+				Description: "The language settings.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"display_language": {
+							Description: "The language to display Gmail in, formatted as an RFC 3066 Language Tag, " +
+								"such as `en-GB` or `fr`. Gmail may save a different variant of the language if " +
+								"the requested one isn't supported when it's written, in which case the configured " +
+								"language is kept as long as Gmail keeps that variant.",
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"saved_display_language": {
+							Description: "The language saved by Gmail, which is a different variant of " +
+								"`display_language` if the requested one isn't supported.",
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceGmailSettingsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	primaryEmail := d.Get("primary_email").(string)
+	gmailService, diags := client.NewGmailService(ctx, primaryEmail)
+	if diags.HasError() {
+		return diags
+	}
+
+	settingsService, diags := GetGmailSettingsService(gmailService)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Creating Gmail Settings %q", primaryEmail)
+
+	written := map[string]bool{}
+	for _, block := range gmailSettingsBlocks {
+		values := d.Get(block).([]interface{})
+		if len(values) == 0 || values[0] == nil {
+			continue
+		}
+
+		if err := updateGmailSetting(settingsService, block, values[0].(map[string]interface{})); err != nil {
+			return diag.FromErr(err)
+		}
+		written[block] = true
+	}
+
+	d.SetId(primaryEmail)
+
+	log.Printf("[DEBUG] Finished creating Gmail Settings %q", d.Id())
+
+	return readGmailSettings(ctx, d, meta, written)
+}
+
+func resourceGmailSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readGmailSettings(ctx, d, meta, nil)
+}
+
+// readGmailSettings reads the managed settings. written are the blocks that were just written, for
+// which the values Gmail saved differently from the configured ones are compared with them.
+func readGmailSettings(ctx context.Context, d *schema.ResourceData, meta interface{}, written map[string]bool) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	gmailService, diags := client.NewGmailService(ctx, d.Get("primary_email").(string))
+	if diags.HasError() {
+		return diags
+	}
+
+	settingsService, diags := GetGmailSettingsService(gmailService)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Getting Gmail Settings %q", d.Id())
+
+	// only the managed settings are read, so that changes to the others don't show up as drift
+	for _, block := range gmailSettingsBlocks {
+		values := d.Get(block).([]interface{})
+		if len(values) == 0 {
+			continue
+		}
+
+		configured, _ := values[0].(map[string]interface{})
+		setting, err := getGmailSetting(settingsService, block, configured)
+		if err != nil {
+			return handleNotFoundError(err, d, d.Id())
+		}
+		keepGmailSavedValue(block, setting, configured, written[block])
+
+		if err := d.Set(block, []interface{}{setting}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[DEBUG] Finished getting Gmail Settings %q", d.Id())
+
+	return nil
+}
+
+func resourceGmailSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	gmailService, diags := client.NewGmailService(ctx, d.Get("primary_email").(string))
+	if diags.HasError() {
+		return diags
+	}
+
+	settingsService, diags := GetGmailSettingsService(gmailService)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Updating Gmail Settings %q", d.Id())
+
+	written := map[string]bool{}
+	for _, block := range gmailSettingsBlocks {
+		if !d.HasChange(block) {
+			continue
+		}
+
+		// settings whose block was removed are no longer managed, and are left as they are
+		values := d.Get(block).([]interface{})
+		if len(values) == 0 || values[0] == nil {
+			continue
+		}
+
+		if err := updateGmailSetting(settingsService, block, values[0].(map[string]interface{})); err != nil {
+			return diag.FromErr(err)
+		}
+		written[block] = true
+	}
+
+	log.Printf("[DEBUG] Finished updating Gmail Settings %q", d.Id())
+
+	return readGmailSettings(ctx, d, meta, written)
+}
+
+func resourceGmailSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	gmailService, diags := client.NewGmailService(ctx, d.Get("primary_email").(string))
+	if diags.HasError() {
+		return diags
+	}
+
+	settingsService, diags := GetGmailSettingsService(gmailService)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Deleting Gmail Settings %q", d.Id())
+
+	for _, block := range gmailSettingsBlocks {
+		if len(d.Get(block).([]interface{})) == 0 {
+			continue
+		}
+
+		if err := restoreGmailSetting(settingsService, block); err != nil {
+			return handleNotFoundError(err, d, d.Id())
+		}
+	}
+
+	log.Printf("[DEBUG] Finished deleting Gmail Settings %q", d.Id())
+
+	return nil
+}
+
+func resourceGmailSettingsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("primary_email", d.Id())
+
+	// all settings are imported, the blocks that aren't configured can be removed to stop managing them
+	for _, block := range gmailSettingsBlocks {
+		d.Set(block, []interface{}{map[string]interface{}{}})
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// updateGmailSetting updates the setting of the given block to the configured values.
+func updateGmailSetting(settingsService *gmail.UsersSettingsService, block string, values map[string]interface{}) error {
+	var err error
+
+	switch block {
+	case "vacation":
+		var vacation *gmail.VacationSettings
+		vacation, err = expandGmailVacationSettings(values)
+		if err == nil {
+			_, err = settingsService.UpdateVacation("me", vacation).Do()
+		}
+	case "imap":
+		_, err = settingsService.UpdateImap("me", &gmail.ImapSettings{
+			Enabled:         values["enabled"].(bool),
+			AutoExpunge:     values["auto_expunge"].(bool),
+			ExpungeBehavior: values["expunge_behavior"].(string),
+			MaxFolderSize:   int64(values["max_folder_size"].(int)),
+			ForceSendFields: []string{"Enabled", "AutoExpunge", "MaxFolderSize"},
+		}).Do()
+	case "pop":
+		_, err = settingsService.UpdatePop("me", &gmail.PopSettings{
+			AccessWindow: values["access_window"].(string),
+			Disposition:  values["disposition"].(string),
+		}).Do()
+	case "auto_forwarding":
+		_, err = settingsService.UpdateAutoForwarding("me", &gmail.AutoForwarding{
+			Enabled:         values["enabled"].(bool),
+			EmailAddress:    values["email_address"].(string),
+			Disposition:     values["disposition"].(string),
+			ForceSendFields: []string{"Enabled"},
+		}).Do()
+	case "language":
+		_, err = settingsService.UpdateLanguage("me", &gmail.LanguageSettings{
+			DisplayLanguage: values["display_language"].(string),
+		}).Do()
+	default:
+		err = fmt.Errorf("unknown gmail setting %s", block)
+	}
+
+	return err
+}
+
+// getGmailSetting returns the values of the setting of the given block. Configured values that the
+// API doesn't return when the setting is disabled are kept.
+func getGmailSetting(settingsService *gmail.UsersSettingsService, block string, configured map[string]interface{}) (map[string]interface{}, error) {
+	keep := func(key, value string) string {
+		if (value == "" || value == "dispositionUnspecified") && configured != nil {
+			if v, ok := configured[key].(string); ok {
+				return v
+			}
+		}
+		return value
+	}
+
+	switch block {
+	case "vacation":
+		vacation, err := settingsService.GetVacation("me").Do()
+		if err != nil {
+			return nil, err
+		}
+		return flattenGmailVacationSettings(vacation, configured), nil
+	case "imap":
+		imap, err := settingsService.GetImap("me").Do()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"enabled":          imap.Enabled,
+			"auto_expunge":     imap.AutoExpunge,
+			"expunge_behavior": imap.ExpungeBehavior,
+			"max_folder_size":  int(imap.MaxFolderSize),
+		}, nil
+	case "pop":
+		pop, err := settingsService.GetPop("me").Do()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"access_window": pop.AccessWindow,
+			"disposition":   keep("disposition", pop.Disposition),
+		}, nil
+	case "auto_forwarding":
+		autoForwarding, err := settingsService.GetAutoForwarding("me").Do()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"enabled":       autoForwarding.Enabled,
+			"email_address": keep("email_address", autoForwarding.EmailAddress),
+			"disposition":   keep("disposition", autoForwarding.Disposition),
+		}, nil
+	case "language":
+		language, err := settingsService.GetLanguage("me").Do()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"display_language":       language.DisplayLanguage,
+			"saved_display_language": language.DisplayLanguage,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown gmail setting %s", block)
+}
+
+// restoreGmailSetting restores the Google defaults of the setting of the given block. The default
+// language depends on the domain, so it's left unchanged.
+func restoreGmailSetting(settingsService *gmail.UsersSettingsService, block string) error {
+	var err error
+
+	switch block {
+	case "vacation":
+		_, err = settingsService.UpdateVacation("me", &gmail.VacationSettings{
+			EnableAutoReply: false,
+			ForceSendFields: []string{"EnableAutoReply"},
+		}).Do()
+	case "imap":
+		_, err = settingsService.UpdateImap("me", &gmail.ImapSettings{
+			Enabled:         true,
+			AutoExpunge:     true,
+			ExpungeBehavior: "archive",
+			ForceSendFields: []string{"MaxFolderSize"},
+		}).Do()
+	case "pop":
+		_, err = settingsService.UpdatePop("me", &gmail.PopSettings{
+			AccessWindow: "disabled",
+			Disposition:  "leaveInInbox",
+		}).Do()
+	case "auto_forwarding":
+		_, err = settingsService.UpdateAutoForwarding("me", &gmail.AutoForwarding{
+			Enabled:         false,
+			ForceSendFields: []string{"Enabled"},
+		}).Do()
+	case "language":
+		log.Printf("[DEBUG] Leaving the Gmail display language unchanged")
+	default:
+		err = fmt.Errorf("unknown gmail setting %s", block)
+	}
+
+	return err
+}
+
+func expandGmailVacationSettings(values map[string]interface{}) (*gmail.VacationSettings, error) {
+	vacation := &gmail.VacationSettings{
+		EnableAutoReply:       values["enable_auto_reply"].(bool),
+		ResponseSubject:       values["response_subject"].(string),
+		ResponseBodyPlainText: values["response_body_plain_text"].(string),
+		ResponseBodyHtml:      values["response_body_html"].(string),
+		RestrictToContacts:    values["restrict_to_contacts"].(bool),
+		RestrictToDomain:      values["restrict_to_domain"].(bool),
+		ForceSendFields:       []string{"EnableAutoReply", "RestrictToContacts", "RestrictToDomain"},
+	}
+
+	if startTime := values["start_time"].(string); startTime != "" {
+		t, err := time.Parse(time.RFC3339, startTime)
+		if err != nil {
+			return nil, err
+		}
+		vacation.StartTime = t.UnixMilli()
+	}
+
+	if endTime := values["end_time"].(string); endTime != "" {
+		t, err := time.Parse(time.RFC3339, endTime)
+		if err != nil {
+			return nil, err
+		}
+		vacation.EndTime = t.UnixMilli()
+	}
+
+	return vacation, nil
+}
+
+func flattenGmailVacationSettings(vacation *gmail.VacationSettings, configured map[string]interface{}) map[string]interface{} {
+	// the configured time is kept when it's the same instant, as it may use another offset than UTC
+	formatTime := func(key string, ms int64) string {
+		if configured != nil {
+			if v, ok := configured[key].(string); ok && v != "" {
+				if t, err := time.Parse(time.RFC3339, v); err == nil && t.UnixMilli() == ms {
+					return v
+				}
+			}
+		}
+		return formatUnixMilli(ms)
+	}
+
+	return map[string]interface{}{
+		"enable_auto_reply":        vacation.EnableAutoReply,
+		"response_subject":         vacation.ResponseSubject,
+		"response_body_plain_text": vacation.ResponseBodyPlainText,
+		"response_body_html":       vacation.ResponseBodyHtml,
+		"saved_response_body_html": vacation.ResponseBodyHtml,
+		"restrict_to_contacts":     vacation.RestrictToContacts,
+		"restrict_to_domain":       vacation.RestrictToDomain,
+		"start_time":               formatTime("start_time", vacation.StartTime),
+		"end_time":                 formatTime("end_time", vacation.EndTime),
+	}
+}
+
+// gmailSavedValues are the values that Gmail may save differently from the written ones, by block: the
+// key of the value, the key of the value Gmail saved, and whether a saved value is equivalent to the
+// written one.
+var gmailSavedValues = map[string]struct {
+	key, savedKey string
+	equivalent    func(written, saved string) bool
+}{
+	"vacation": {"response_body_html", "saved_response_body_html", func(written, saved string) bool {
+		return gmailHtmlText(written) == gmailHtmlText(saved)
+	}},
+	"language": {"display_language", "saved_display_language", gmailLanguagesEquivalent},
+}
+
+// keepGmailSavedValue keeps the configured value of the setting when Gmail saved an equivalent one. It's
+// only compared right after the value is written, and afterwards it's kept as long as Gmail has the same
+// saved value, so that any other change shows up as drift.
+func keepGmailSavedValue(block string, setting, configured map[string]interface{}, written bool) {
+	values, ok := gmailSavedValues[block]
+	if !ok || configured == nil {
+		return
+	}
+
+	value, _ := configured[values.key].(string)
+	saved := setting[values.savedKey].(string)
+	if value == "" || value == saved {
+		return
+	}
+
+	if written {
+		if values.equivalent(value, saved) {
+			setting[values.key] = value
+		}
+		return
+	}
+
+	if previous, _ := configured[values.savedKey].(string); previous == saved {
+		setting[values.key] = value
+	}
+}
+
+var gmailHtmlTagRegexp = regexp.MustCompile(`<[^>]*>`)
+
+// gmailHtmlText returns the text of the HTML with its markup removed and whitespace collapsed.
+func gmailHtmlText(s string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(gmailHtmlTagRegexp.ReplaceAllString(s, " "))), " ")
+}
+
+// gmailLanguagesEquivalent returns whether the language tags are variants of the same language,
+// such as `en-GB` and `en`.
+func gmailLanguagesEquivalent(a, b string) bool {
+	primary := func(tag string) string {
+		return strings.ToLower(strings.SplitN(strings.ReplaceAll(tag, "_", "-"), "-", 2)[0])
+	}
+	return a != "" && b != "" && primary(a) == primary(b)
+}
@@ -0,0 +1,314 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"google.golang.org/api/gmail/v1"
+)
+
+// newFakeGmailSettingsServer returns a settings service for a fake Gmail API that serves the given
+// settings by name and echoes updates, and a func returning the requests received.
+func newFakeGmailSettingsServer(t *testing.T, settings map[string]string) (*gmail.UsersSettingsService, func() []string) {
+	client, requests := newFakeApiServer(t, "/gmail/v1/users/me/settings/", func(w http.ResponseWriter, r *http.Request, setting string) {
+		if r.Method == http.MethodPut {
+			io.Copy(w, r.Body)
+			return
+		}
+
+		if resp, ok := settings[setting]; ok && r.Method == http.MethodGet {
+			fmt.Fprint(w, resp)
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"code": 404, "message": "Not Found"}}`)
+	})
+
+	return newFakeGmailService(t, client).Users.Settings, requests
+}
+
+func TestGmailVacationSettings(t *testing.T) {
+	t.Parallel()
+
+	configured := map[string]interface{}{
+		"enable_auto_reply":        true,
+		"response_subject":         "Out of office",
+		"response_body_plain_text": "I'm away.",
+		"response_body_html":       "",
+		"saved_response_body_html": "",
+		"restrict_to_contacts":     false,
+		"restrict_to_domain":       true,
+		"start_time":               "2024-07-01T09:00:00+02:00",
+		"end_time":                 "",
+	}
+
+	vacation, err := expandGmailVacationSettings(configured)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if vacation.StartTime != 1719817200000 || vacation.EndTime != 0 {
+		t.Errorf("unexpected times %d, %d", vacation.StartTime, vacation.EndTime)
+	}
+
+	// the configured offset is kept for the same instant
+	if actual := flattenGmailVacationSettings(vacation, configured); !reflect.DeepEqual(actual, configured) {
+		t.Errorf("settings not equal\n\nactual %v\n\nexpected %v", actual, configured)
+	}
+
+	vacation.StartTime = 1719820800000
+	vacation.EndTime = 1720425600000
+	actual := flattenGmailVacationSettings(vacation, configured)
+	if actual["start_time"] != "2024-07-01T08:00:00Z" || actual["end_time"] != "2024-07-08T08:00:00Z" {
+		t.Errorf("unexpected times %v, %v", actual["start_time"], actual["end_time"])
+	}
+}
+
+func TestResourceGmailSettingsImport(t *testing.T) {
+	t.Parallel()
+
+	d := resourceGmailSettings().TestResourceData()
+	d.SetId("jane@example.com")
+
+	if _, err := resourceGmailSettingsImport(context.Background(), d, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Get("primary_email") != "jane@example.com" {
+		t.Errorf("unexpected primary email %v", d.Get("primary_email"))
+	}
+
+	// every setting has a block, so that Read fills them in
+	for _, block := range gmailSettingsBlocks {
+		if len(d.Get(block).([]interface{})) != 1 {
+			t.Errorf("expected a %s block, got %v", block, d.Get(block))
+		}
+	}
+}
+
+func TestUpdateGmailSetting(t *testing.T) {
+	t.Parallel()
+
+	settingsService, requests := newFakeGmailSettingsServer(t, nil)
+
+	settings := map[string]map[string]interface{}{
+		"imap": {"enabled": false, "auto_expunge": true, "expunge_behavior": "archive", "max_folder_size": 0},
+		"pop":  {"access_window": "disabled", "disposition": "leaveInInbox"},
+		"auto_forwarding": {
+			"enabled":       true,
+			"email_address": "archive@example.com",
+			"disposition":   "archive",
+		},
+		"language": {"display_language": "en-GB"},
+	}
+	for _, block := range []string{"imap", "pop", "auto_forwarding", "language"} {
+		if err := updateGmailSetting(settingsService, block, settings[block]); err != nil {
+			t.Fatalf("%s: unexpected error: %v", block, err)
+		}
+	}
+
+	expected := []string{
+		`PUT imap {"autoExpunge":true,"enabled":false,"expungeBehavior":"archive","maxFolderSize":0}`,
+		`PUT pop {"accessWindow":"disabled","disposition":"leaveInInbox"}`,
+		`PUT autoForwarding {"disposition":"archive","emailAddress":"archive@example.com","enabled":true}`,
+		`PUT language {"displayLanguage":"en-GB"}`,
+	}
+	if actual := requests(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("requests not equal\n\nactual %v\n\nexpected %v", actual, expected)
+	}
+}
+
+func TestGetGmailSetting(t *testing.T) {
+	t.Parallel()
+
+	settingsService, _ := newFakeGmailSettingsServer(t, map[string]string{
+		"pop":            `{"accessWindow": "disabled"}`,
+		"autoForwarding": `{"enabled": false}`,
+		"language":       `{"displayLanguage": "en"}`,
+	})
+
+	pop, err := getGmailSetting(settingsService, "pop", map[string]interface{}{"access_window": "fromNowOn", "disposition": "trash"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]interface{}{"access_window": "disabled", "disposition": "trash"}; !reflect.DeepEqual(pop, want) {
+		t.Errorf("expected %v, got %v", want, pop)
+	}
+
+	// nothing is configured on import
+	autoForwarding, err := getGmailSetting(settingsService, "auto_forwarding", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]interface{}{"enabled": false, "email_address": "", "disposition": ""}; !reflect.DeepEqual(autoForwarding, want) {
+		t.Errorf("expected %v, got %v", want, autoForwarding)
+	}
+
+	language, err := getGmailSetting(settingsService, "language", map[string]interface{}{"display_language": "en-AU"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]interface{}{"display_language": "en", "saved_display_language": "en"}; !reflect.DeepEqual(language, want) {
+		t.Errorf("expected %v, got %v", want, language)
+	}
+}
+
+func TestKeepGmailSavedValue(t *testing.T) {
+	t.Parallel()
+
+	language := func(displayLanguage, saved string) map[string]interface{} {
+		return map[string]interface{}{"display_language": displayLanguage, "saved_display_language": saved}
+	}
+
+	cases := map[string]struct {
+		configured map[string]interface{}
+		gmail      string
+		written    bool
+		want       string
+	}{
+		// right after a write, the configured language is kept when Gmail saved another variant of it
+		"substituted":     {language("en-AU", ""), "en", true, "en-AU"},
+		"not substituted": {language("fr", ""), "en", true, "en"},
+		// afterwards, it's only kept as long as Gmail has the variant it saved
+		"unchanged": {language("en-AU", "en"), "en", false, "en-AU"},
+		"drifted":   {language("en-AU", "en"), "en-US", false, "en-US"},
+		"imported":  {language("en-AU", ""), "en", false, "en"},
+	}
+
+	for name, tc := range cases {
+		setting := language(tc.gmail, tc.gmail)
+		keepGmailSavedValue("language", setting, tc.configured, tc.written)
+		if want := language(tc.want, tc.gmail); !reflect.DeepEqual(setting, want) {
+			t.Errorf("%s: expected %v, got %v", name, want, setting)
+		}
+	}
+
+	// the configured HTML is kept when Gmail only sanitized it
+	configured := map[string]interface{}{"response_body_html": "<p onclick='x()'>I&#39;m away.</p>"}
+	setting := map[string]interface{}{"response_body_html": "<p>I'm away.</p>", "saved_response_body_html": "<p>I'm away.</p>"}
+	keepGmailSavedValue("vacation", setting, configured, true)
+	if setting["response_body_html"] != configured["response_body_html"] {
+		t.Errorf("expected the configured HTML, got %v", setting["response_body_html"])
+	}
+
+	// but only right after it's written
+	setting = map[string]interface{}{"response_body_html": "<b>I'm away.</b>", "saved_response_body_html": "<b>I'm away.</b>"}
+	configured["saved_response_body_html"] = "<p>I'm away.</p>"
+	keepGmailSavedValue("vacation", setting, configured, false)
+	if setting["response_body_html"] != "<b>I'm away.</b>" {
+		t.Errorf("expected the changed HTML, got %v", setting["response_body_html"])
+	}
+}
+
+func TestRestoreGmailSetting(t *testing.T) {
+	t.Parallel()
+
+	settingsService, requests := newFakeGmailSettingsServer(t, nil)
+
+	for _, block := range gmailSettingsBlocks {
+		if err := restoreGmailSetting(settingsService, block); err != nil {
+			t.Fatalf("%s: unexpected error: %v", block, err)
+		}
+	}
+
+	expected := []string{
+		`PUT vacation {"enableAutoReply":false}`,
+		`PUT imap {"autoExpunge":true,"enabled":true,"expungeBehavior":"archive","maxFolderSize":0}`,
+		`PUT pop {"accessWindow":"disabled","disposition":"leaveInInbox"}`,
+		`PUT autoForwarding {"enabled":false}`,
+	}
+	if actual := requests(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("requests not equal\n\nactual %v\n\nexpected %v", actual, expected)
+	}
+}
+
+func TestAccResourceGmailSettings_basic(t *testing.T) {
+	gmailUser := os.Getenv("GOOGLEWORKSPACE_TEST_GMAIL_USER")
+
+	if gmailUser == "" {
+		t.Skip("GOOGLEWORKSPACE_TEST_GMAIL_USER needs to be set to run this test")
+	}
+
+	data := map[string]interface{}{
+		"gmailUser": gmailUser,
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGmailSettings_vacation(data),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_gmail_settings.test", "vacation.0.enable_auto_reply", "true"),
+					resource.TestCheckResourceAttr("googleworkspace_gmail_settings.test", "imap.#", "0"),
+				),
+			},
+			{
+				Config: testAccGmailSettings_full(data),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_gmail_settings.test", "imap.0.enabled", "false"),
+					resource.TestCheckResourceAttr("googleworkspace_gmail_settings.test", "pop.0.access_window", "disabled"),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_gmail_settings.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the language isn't configured but is imported, and imported times are in UTC
+				ImportStateVerifyIgnore: []string{"language", "vacation.0.start_time", "vacation.0.end_time"},
+			},
+		},
+	})
+}
+
+func testAccGmailSettings_vacation(data map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_gmail_settings" "test" {
+  primary_email = "%{gmailUser}"
+
+  vacation {
+    enable_auto_reply        = true
+    response_subject         = "Out of office"
+    response_body_plain_text = "I'm away until July."
+    restrict_to_domain       = true
+    start_time               = "2030-07-01T09:00:00+02:00"
+    end_time                 = "2030-07-08T18:00:00+02:00"
+  }
+}
+`, data)
+}
+
+func testAccGmailSettings_full(data map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_gmail_settings" "test" {
+  primary_email = "%{gmailUser}"
+
+  vacation {
+    enable_auto_reply        = true
+    response_subject         = "Out of office"
+    response_body_plain_text = "I'm away until July."
+    restrict_to_domain       = true
+    start_time               = "2030-07-01T09:00:00+02:00"
+    end_time                 = "2030-07-08T18:00:00+02:00"
+  }
+
+  imap {
+    enabled = false
+  }
+
+  pop {
+    access_window = "disabled"
+  }
+
+  auto_forwarding {
+    enabled = false
+  }
+}
+`, data)
+}
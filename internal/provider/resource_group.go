@@ -86,6 +86,13 @@ func resourceGroup() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"manage_aliases": {
+				Description: "If `false`, `aliases` are neither read nor written, so they can be " +
+					"managed with `googleworkspace_group_alias` resources instead.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
 			"non_editable_aliases": {
 				Description: "asps.list of the group's non-editable alias email addresses that are outside of the " +
 					"account's primary domain or subdomains. These are functioning email addresses used by the group.",
@@ -145,7 +152,7 @@ func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, meta inter
 
 	aliases := d.Get("aliases.#").(int)
 
-	if aliases > 0 {
+	if aliases > 0 && d.Get("manage_aliases").(bool) {
 		aliasesService, diags := GetGroupAliasService(groupsService)
 		if diags.HasError() {
 			return diags
@@ -220,6 +227,12 @@ func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, meta inter
 }
 
 func resourceGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readGroup(ctx, d, meta, true)
+}
+
+// readGroup reads the group into the state. manage_aliases doesn't exist in the group data source,
+// which shares this function, so it's only handled when isResource is set.
+func readGroup(ctx context.Context, d *schema.ResourceData, meta interface{}, isResource bool) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	// use the meta value to retrieve your client from the provider configure method
@@ -245,7 +258,13 @@ func resourceGroupRead(ctx context.Context, d *schema.ResourceData, meta interfa
 	d.Set("description", group.Description)
 	d.Set("admin_created", group.AdminCreated)
 	d.Set("direct_members_count", group.DirectMembersCount)
-	d.Set("aliases", group.Aliases)
+	// manage_aliases is not returned in the response, so default it on import
+	if _, ok := d.GetOkExists("manage_aliases"); !ok && isResource {
+		d.Set("manage_aliases", true)
+	}
+	if !isResource || d.Get("manage_aliases").(bool) {
+		d.Set("aliases", group.Aliases)
+	}
 	d.Set("non_editable_aliases", group.NonEditableAliases)
 	d.Set("etag", group.Etag)
 
@@ -306,7 +325,7 @@ func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 
 	numInserts := 0
-	if d.HasChange("aliases") {
+	if d.HasChange("aliases") && d.Get("manage_aliases").(bool) {
 		old, new := d.GetChange("aliases")
 		oldAliases := listOfInterfacestoStrings(old.([]interface{}))
 		newAliases := listOfInterfacestoStrings(new.([]interface{}))
@@ -0,0 +1,241 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func resourceGroupAlias() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Group Alias resource manages a single alias of a Google Workspace Group, without managing the " +
+			"group. Set `manage_aliases` to `false` on the `googleworkspace_group` to stop it from managing the same " +
+			"aliases. Group Alias resides under the `https://www.googleapis.com/auth/admin.directory.group` client scope.",
+
+		CreateContext: resourceGroupAliasCreate,
+		ReadContext:   resourceGroupAliasRead,
+		DeleteContext: resourceGroupAliasDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGroupAliasImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Description: "Identifies the group in the API request. The value can be the group's email address " +
+					"or unique group ID.",
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"alias": {
+				Description: "The alias email address.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceGroupAliasCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	groupId := d.Get("group_id").(string)
+	alias := d.Get("alias").(string)
+	log.Printf("[DEBUG] Creating Group Alias %q for Group %q", alias, groupId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	groupsService, diags := GetGroupsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliasesService, diags := GetGroupAliasService(groupsService)
+	if diags.HasError() {
+		return diags
+	}
+
+	group, err := groupsService.Get(groupId).Fields("id", "email").Do()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// an alias can only be attached to a single user or group
+	owner, err := findAliasOwner(directoryService, alias)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if owner != "" && !strings.EqualFold(owner, fmt.Sprintf("group %s", group.Email)) {
+		return diag.Errorf("alias %s is already attached to %s", alias, owner)
+	}
+
+	// an alias already attached to the group is adopted
+	if owner == "" {
+		_, err = aliasesService.Insert(group.Id, &directory.Alias{Alias: alias}).Do()
+		if isApiErrorWithCode(err, http.StatusConflict) {
+			return diag.Errorf("alias %s is already attached to another user or group", alias)
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("groups/%s/aliases/%s", groupId, alias))
+
+	// INSERT will respond with the Alias that will be created, however, it is eventually consistent
+	// After INSERT, the etag of the group's aliases is updated,
+	// once we get a consistent etag, we can feel confident that our Alias is also consistent
+	cc := consistencyCheck{
+		resourceType: "group alias",
+		timeout:      d.Timeout(schema.TimeoutCreate),
+	}
+	err = retryTimeDuration(ctx, d.Timeout(schema.TimeoutCreate), func() error {
+		var retryErr error
+
+		if cc.reachedConsistency(1) {
+			return nil
+		}
+
+		aliases, retryErr := aliasesService.List(group.Id).IfNoneMatch(cc.lastEtag).Do()
+		if googleapi.IsNotModified(retryErr) {
+			cc.currConsistent += 1
+		} else if retryErr != nil {
+			return fmt.Errorf("unexpected error during retries of %s: %s", cc.resourceType, retryErr)
+		} else if !aliasInList(flattenAliases(aliases), alias) {
+			// alias was not found yet therefore setting currConsistent back to null value
+			cc.currConsistent = 0
+		} else {
+			cc.handleNewEtag(aliases.Etag)
+		}
+
+		return fmt.Errorf("timed out while waiting for %s to be inserted", cc.resourceType)
+	})
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finished creating Group Alias %q for Group %q", alias, groupId)
+
+	return resourceGroupAliasRead(ctx, d, meta)
+}
+
+func resourceGroupAliasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	groupId := d.Get("group_id").(string)
+	alias := d.Get("alias").(string)
+	log.Printf("[DEBUG] Getting Group Alias %q for Group %q", alias, groupId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	groupsService, diags := GetGroupsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliasesService, diags := GetGroupAliasService(groupsService)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliases, err := aliasesService.List(groupId).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	if !aliasInList(flattenAliases(aliases), alias) {
+		log.Printf("[WARN] Removing Group Alias %q because it's gone", d.Id())
+		d.SetId("")
+
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished getting Group Alias %q for Group %q", alias, groupId)
+
+	return diags
+}
+
+func resourceGroupAliasDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	groupId := d.Get("group_id").(string)
+	alias := d.Get("alias").(string)
+	log.Printf("[DEBUG] Deleting Group Alias %q from Group %q", alias, groupId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	groupsService, diags := GetGroupsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliasesService, diags := GetGroupAliasService(groupsService)
+	if diags.HasError() {
+		return diags
+	}
+
+	err := aliasesService.Delete(groupId, alias).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	log.Printf("[DEBUG] Finished deleting Group Alias %q from Group %q", alias, groupId)
+
+	return diags
+}
+
+func resourceGroupAliasImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+
+	// id is of format "groups/<group_id>/aliases/<alias>"
+	if len(parts) != 4 || parts[0] != "groups" || parts[2] != "aliases" {
+		return nil, fmt.Errorf("group alias id (%s) is not of the correct format (groups/<group_id>/aliases/<alias>)", d.Id())
+	}
+
+	d.Set("group_id", parts[1])
+	d.Set("alias", parts[3])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,78 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceGroupAliasImport(t *testing.T) {
+	d := resourceGroupAlias().TestResourceData()
+	d.SetId("groups/01234abcd/aliases/team@example.com")
+
+	if _, err := resourceGroupAliasImport(context.Background(), d, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if d.Get("group_id").(string) != "01234abcd" || d.Get("alias").(string) != "team@example.com" {
+		t.Errorf("unexpected group_id %q and alias %q", d.Get("group_id"), d.Get("alias"))
+	}
+
+	d.SetId("groups/01234abcd/team@example.com")
+	if _, err := resourceGroupAliasImport(context.Background(), d, nil); err == nil {
+		t.Errorf("expected an error for an id of the wrong format")
+	}
+}
+
+func TestAccResourceGroupAlias_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testGroupVals := map[string]interface{}{
+		"domainName": domainName,
+		"groupEmail": fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceGroupAlias_basic(testGroupVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_group_alias.alias", "alias",
+						fmt.Sprintf("%s-alias@%s", testGroupVals["groupEmail"], domainName)),
+					resource.TestCheckResourceAttr("googleworkspace_group.group", "aliases.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_group_alias.alias",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceGroupAlias_basic(testGroupVals map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_group" "group" {
+  email          = "%{groupEmail}@%{domainName}"
+  manage_aliases = false
+}
+
+resource "googleworkspace_group_alias" "alias" {
+  group_id = googleworkspace_group.group.id
+  alias    = "%{groupEmail}-alias@%{domainName}"
+}
+`, testGroupVals)
+}
@@ -470,88 +470,15 @@ func resourceGroupSettingsRead(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
-	// Convert strings to bools
-	allowExternalMembers, err := strconv.ParseBool(group.AllowExternalMembers)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	allowWebPosting, err := strconv.ParseBool(group.AllowWebPosting)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	isArchived, err := strconv.ParseBool(group.IsArchived)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	archiveOnly, err := strconv.ParseBool(group.ArchiveOnly)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	includeCustomFooter, err := strconv.ParseBool(group.IncludeCustomFooter)
+	groupSettings, err := flattenGroupSettings(group)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	sendMessageDenyNotification, err := strconv.ParseBool(group.SendMessageDenyNotification)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	membersCanPostAsTheGroup, err := strconv.ParseBool(group.MembersCanPostAsTheGroup)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	includeInGlobalAddressList, err := strconv.ParseBool(group.IncludeInGlobalAddressList)
-	if err != nil {
-		return diag.FromErr(err)
+	for k, v := range groupSettings {
+		d.Set(k, v)
 	}
 
-	customRolesEnabledForSettingsToBeMerged, err := strconv.ParseBool(group.CustomRolesEnabledForSettingsToBeMerged)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	enableCollaborativeInbox, err := strconv.ParseBool(group.EnableCollaborativeInbox)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	d.Set("email", group.Email)
-	d.Set("name", group.Name)
-	d.Set("description", group.Description)
-	d.Set("who_can_join", group.WhoCanJoin)
-	d.Set("who_can_view_membership", group.WhoCanViewMembership)
-	d.Set("who_can_view_group", group.WhoCanViewGroup)
-	d.Set("allow_external_members", allowExternalMembers)
-	d.Set("who_can_post_message", group.WhoCanPostMessage)
-	d.Set("allow_web_posting", allowWebPosting)
-	d.Set("primary_language", group.PrimaryLanguage)
-	d.Set("is_archived", isArchived)
-	d.Set("archive_only", archiveOnly)
-	d.Set("message_moderation_level", group.MessageModerationLevel)
-	d.Set("spam_moderation_level", group.SpamModerationLevel)
-	d.Set("reply_to", group.ReplyTo)
-	d.Set("custom_reply_to", group.CustomReplyTo)
-	d.Set("include_custom_footer", includeCustomFooter)
-	d.Set("custom_footer_text", group.CustomFooterText)
-	d.Set("send_message_deny_notification", sendMessageDenyNotification)
-	d.Set("default_message_deny_notification_text", group.DefaultMessageDenyNotificationText)
-	d.Set("members_can_post_as_the_group", membersCanPostAsTheGroup)
-	d.Set("include_in_global_address_list", includeInGlobalAddressList)
-	d.Set("who_can_leave_group", group.WhoCanLeaveGroup)
-	d.Set("who_can_contact_owner", group.WhoCanContactOwner)
-	d.Set("who_can_moderate_members", group.WhoCanModerateMembers)
-	d.Set("who_can_moderate_content", group.WhoCanModerateContent)
-	d.Set("who_can_assist_content", group.WhoCanAssistContent)
-	d.Set("custom_roles_enabled_for_settings_to_be_merged", customRolesEnabledForSettingsToBeMerged)
-	d.Set("enable_collaborative_inbox", enableCollaborativeInbox)
-	d.Set("who_can_discover_group", group.WhoCanDiscoverGroup)
-
 	d.SetId(group.Email)
 
 	return diags
@@ -743,3 +670,91 @@ func resourceGroupSettingsDelete(ctx context.Context, d *schema.ResourceData, me
 
 	return nil
 }
+
+// flattenGroupSettings converts the string encoded booleans returned by the Groups Settings API
+// and returns the settings keyed by their schema attribute names.
+func flattenGroupSettings(group *groupssettings.Groups) (map[string]interface{}, error) {
+	// Convert strings to bools
+	allowExternalMembers, err := strconv.ParseBool(group.AllowExternalMembers)
+	if err != nil {
+		return nil, err
+	}
+
+	allowWebPosting, err := strconv.ParseBool(group.AllowWebPosting)
+	if err != nil {
+		return nil, err
+	}
+
+	isArchived, err := strconv.ParseBool(group.IsArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	archiveOnly, err := strconv.ParseBool(group.ArchiveOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	includeCustomFooter, err := strconv.ParseBool(group.IncludeCustomFooter)
+	if err != nil {
+		return nil, err
+	}
+
+	sendMessageDenyNotification, err := strconv.ParseBool(group.SendMessageDenyNotification)
+	if err != nil {
+		return nil, err
+	}
+
+	membersCanPostAsTheGroup, err := strconv.ParseBool(group.MembersCanPostAsTheGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	includeInGlobalAddressList, err := strconv.ParseBool(group.IncludeInGlobalAddressList)
+	if err != nil {
+		return nil, err
+	}
+
+	customRolesEnabledForSettingsToBeMerged, err := strconv.ParseBool(group.CustomRolesEnabledForSettingsToBeMerged)
+	if err != nil {
+		return nil, err
+	}
+
+	enableCollaborativeInbox, err := strconv.ParseBool(group.EnableCollaborativeInbox)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"email":                                          group.Email,
+		"name":                                           group.Name,
+		"description":                                    group.Description,
+		"who_can_join":                                   group.WhoCanJoin,
+		"who_can_view_membership":                        group.WhoCanViewMembership,
+		"who_can_view_group":                             group.WhoCanViewGroup,
+		"allow_external_members":                         allowExternalMembers,
+		"who_can_post_message":                           group.WhoCanPostMessage,
+		"allow_web_posting":                              allowWebPosting,
+		"primary_language":                               group.PrimaryLanguage,
+		"is_archived":                                    isArchived,
+		"archive_only":                                   archiveOnly,
+		"message_moderation_level":                       group.MessageModerationLevel,
+		"spam_moderation_level":                          group.SpamModerationLevel,
+		"reply_to":                                       group.ReplyTo,
+		"custom_reply_to":                                group.CustomReplyTo,
+		"include_custom_footer":                          includeCustomFooter,
+		"custom_footer_text":                             group.CustomFooterText,
+		"send_message_deny_notification":                 sendMessageDenyNotification,
+		"default_message_deny_notification_text":         group.DefaultMessageDenyNotificationText,
+		"members_can_post_as_the_group":                  membersCanPostAsTheGroup,
+		"include_in_global_address_list":                 includeInGlobalAddressList,
+		"who_can_leave_group":                            group.WhoCanLeaveGroup,
+		"who_can_contact_owner":                          group.WhoCanContactOwner,
+		"who_can_moderate_members":                       group.WhoCanModerateMembers,
+		"who_can_moderate_content":                       group.WhoCanModerateContent,
+		"who_can_assist_content":                         group.WhoCanAssistContent,
+		"custom_roles_enabled_for_settings_to_be_merged": customRolesEnabledForSettingsToBeMerged,
+		"enable_collaborative_inbox":                     enableCollaborativeInbox,
+		"who_can_discover_group":                         group.WhoCanDiscoverGroup,
+	}, nil
+}
@@ -0,0 +1,478 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+// orgUnitMembersMaxDevicesPerMove is the maximum number of devices moved by a single moveDevicesToOu call.
+const orgUnitMembersMaxDevicesPerMove = 50
+
+func resourceOrgUnitMembers() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Org Unit Members resource declares the complete set of users, and optionally Chrome OS " +
+			"devices, placed directly in a Google Workspace Org Unit, without managing the users. Members added " +
+			"outside of Terraform are detected and moved to `fallback_org_unit_path`, as are members removed from " +
+			"the configuration and, on destroy, all members. Each user is moved with its own `users.update` request, " +
+			"up to `concurrency` at a time, as the Directory API has no batch move for users, while devices are " +
+			"moved in batches of 50. Do not set `org_unit_path` on the " +
+			"`googleworkspace_user` of a member. Org Unit Members resides under the " +
+			"`https://www.googleapis.com/auth/admin.directory.user` client scope, and devices under the " +
+			"`https://www.googleapis.com/auth/admin.directory.device.chromeos` client scope.",
+
+		CreateContext: resourceOrgUnitMembersCreate,
+		ReadContext:   resourceOrgUnitMembersRead,
+		UpdateContext: resourceOrgUnitMembersUpdate,
+		DeleteContext: resourceOrgUnitMembersDelete,
+
+		CustomizeDiff: resourceOrgUnitMembersCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOrgUnitMembersImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_unit_path": {
+				Description: "The full path of the org unit, e.g. `/Sales`.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				ValidateDiagFunc: validation.ToDiagFunc(func(i interface{}, k string) ([]string, []error) {
+					if err := validateOrgUnitTreePath(i.(string)); err != nil {
+						return nil, []error{err}
+					}
+					return nil, nil
+				}),
+			},
+			"users": {
+				Description: "The primary email addresses of the users in the org unit.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"manage_devices": {
+				Description: "If `true`, `device_ids` is the complete set of Chrome OS devices " +
+					"in the org unit. Otherwise devices are not managed.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"device_ids": {
+				Description: "The unique IDs of the Chrome OS devices in the org unit. Can only be set if " +
+					"`manage_devices` is `true`.",
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"fallback_org_unit_path": {
+				Description: "The full path of the org unit that members removed from the org " +
+					"unit are moved to.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+			},
+			"concurrency": {
+				Description:      "The maximum number of concurrent `users.update` requests moving users.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          10,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 50)),
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceOrgUnitMembersCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("manage_devices") || d.Get("manage_devices").(bool) {
+		return nil
+	}
+
+	if d.Get("device_ids").(*schema.Set).Len() > 0 {
+		return fmt.Errorf("device_ids can only be set if manage_devices is true")
+	}
+
+	return nil
+}
+
+func resourceOrgUnitMembersCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	orgUnitPath := d.Get("org_unit_path").(string)
+	log.Printf("[DEBUG] Creating Org Unit Members for OrgUnit %q", orgUnitPath)
+
+	diags := applyOrgUnitMembers(ctx, d, meta, d.Timeout(schema.TimeoutCreate))
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(orgUnitPath)
+
+	log.Printf("[DEBUG] Finished creating Org Unit Members for OrgUnit %q", orgUnitPath)
+
+	return resourceOrgUnitMembersRead(ctx, d, meta)
+}
+
+func resourceOrgUnitMembersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	orgUnitPath := d.Get("org_unit_path").(string)
+	log.Printf("[DEBUG] Getting Org Unit Members for OrgUnit %q", orgUnitPath)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	orgUnitsService, diags := GetOrgUnitsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	_, err := orgUnitsService.Get(client.Customer, strings.TrimPrefix(orgUnitPath, "/")).Fields("orgUnitId").Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	emails, err := listOrgUnitUserEmails(ctx, usersService, client.Customer, orgUnitPath)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// keep the configured spelling of email addresses, as they are case-insensitive
+	users := matchConfiguredMembers(listOfInterfacestoStrings(d.Get("users").(*schema.Set).List()), emails)
+	if err := d.Set("users", users); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("manage_devices").(bool) {
+		chromeosDevicesService, diags := GetChromeosDevicesService(directoryService)
+		if diags.HasError() {
+			return diags
+		}
+
+		deviceIds, err := listOrgUnitDeviceIds(ctx, chromeosDevicesService, client.Customer, orgUnitPath)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("device_ids", deviceIds); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[DEBUG] Finished getting Org Unit Members for OrgUnit %q", orgUnitPath)
+
+	return diags
+}
+
+func resourceOrgUnitMembersUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	orgUnitPath := d.Get("org_unit_path").(string)
+	log.Printf("[DEBUG] Updating Org Unit Members for OrgUnit %q", orgUnitPath)
+
+	diags := applyOrgUnitMembers(ctx, d, meta, d.Timeout(schema.TimeoutUpdate))
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished updating Org Unit Members for OrgUnit %q", orgUnitPath)
+
+	return resourceOrgUnitMembersRead(ctx, d, meta)
+}
+
+func resourceOrgUnitMembersDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	orgUnitPath := d.Get("org_unit_path").(string)
+	log.Printf("[DEBUG] Deleting Org Unit Members for OrgUnit %q", orgUnitPath)
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	orgUnitsService, diags := GetOrgUnitsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	// the org unit can't have members once it's gone
+	_, err := orgUnitsService.Get(client.Customer, strings.TrimPrefix(orgUnitPath, "/")).Fields("orgUnitId").Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	// removing every member moves them all to the fallback org unit
+	d.Set("users", []string{})
+	d.Set("device_ids", []string{})
+
+	diags = applyOrgUnitMembers(ctx, d, meta, d.Timeout(schema.TimeoutDelete))
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished deleting Org Unit Members for OrgUnit %q", orgUnitPath)
+
+	return diags
+}
+
+func resourceOrgUnitMembersImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// id is the org unit path
+	if err := validateOrgUnitTreePath(d.Id()); err != nil {
+		return nil, fmt.Errorf("org unit members id (%s) is not of the correct format (/<org_unit_path>)", d.Id())
+	}
+
+	d.Set("org_unit_path", d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// applyOrgUnitMembers moves the configured members into the org unit and every other member to the
+// fallback org unit, then waits for the users list to reflect the moves.
+func applyOrgUnitMembers(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	orgUnitPath := d.Get("org_unit_path").(string)
+	fallback := d.Get("fallback_org_unit_path").(string)
+	if strings.EqualFold(orgUnitPath, fallback) {
+		return diag.Errorf("fallback_org_unit_path must differ from org_unit_path %s", orgUnitPath)
+	}
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	concurrency := d.Get("concurrency").(int)
+	desiredUsers := listOfInterfacestoStrings(d.Get("users").(*schema.Set).List())
+
+	emails, err := listOrgUnitUserEmails(ctx, usersService, client.Customer, orgUnitPath)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	add, remove := diffOrgUnitMembers(emails, desiredUsers)
+	if err := moveUsersToOrgUnit(ctx, usersService, add, orgUnitPath, concurrency); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := moveUsersToOrgUnit(ctx, usersService, remove, fallback, concurrency); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("manage_devices").(bool) {
+		chromeosDevicesService, diags := GetChromeosDevicesService(directoryService)
+		if diags.HasError() {
+			return diags
+		}
+
+		deviceIds, err := listOrgUnitDeviceIds(ctx, chromeosDevicesService, client.Customer, orgUnitPath)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		addDevices, removeDevices := diffOrgUnitMembers(deviceIds, listOfInterfacestoStrings(d.Get("device_ids").(*schema.Set).List()))
+		if err := moveDevicesToOrgUnit(ctx, chromeosDevicesService, client.Customer, addDevices, orgUnitPath); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := moveDevicesToOrgUnit(ctx, chromeosDevicesService, client.Customer, removeDevices, fallback); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// devices are moved synchronously, only the users need to be waited for
+	if len(add) == 0 && len(remove) == 0 {
+		return diags
+	}
+
+	// users.list is eventually consistent, wait until it lists exactly the desired users
+	// so that the following read does not report the moved users as drift
+	err = retryTimeDuration(ctx, timeout, func() error {
+		emails, retryErr := listOrgUnitUserEmails(ctx, usersService, client.Customer, orgUnitPath)
+		if retryErr != nil {
+			return fmt.Errorf("unexpected error during retries of org unit members: %s", retryErr)
+		}
+
+		if add, remove := diffOrgUnitMembers(emails, desiredUsers); len(add) > 0 || len(remove) > 0 {
+			return fmt.Errorf("timed out while waiting for org unit members to be moved")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// listOrgUnitUserEmails returns the primary emails of the users directly in the org unit, sorted.
+func listOrgUnitUserEmails(ctx context.Context, usersService *directory.UsersService, customer, orgUnitPath string) ([]string, error) {
+	emails := []string{}
+	err := usersService.List().Customer(customer).Query(orgUnitPathQuery(orgUnitPath)).
+		Fields("nextPageToken", "users(primaryEmail,orgUnitPath)").Pages(ctx, func(resp *directory.Users) error {
+		for _, user := range resp.Users {
+			// the query also matches the users of sub-org units
+			if strings.EqualFold(user.OrgUnitPath, orgUnitPath) {
+				emails = append(emails, user.PrimaryEmail)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(emails)
+
+	return emails, nil
+}
+
+// listOrgUnitDeviceIds returns the IDs of the Chrome OS devices directly in the org unit, sorted.
+func listOrgUnitDeviceIds(ctx context.Context, chromeosDevicesService *directory.ChromeosdevicesService, customer, orgUnitPath string) ([]string, error) {
+	deviceIds := []string{}
+	err := chromeosDevicesService.List(customer).OrgUnitPath(orgUnitPath).IncludeChildOrgunits(false).
+		Fields("nextPageToken", "chromeosdevices(deviceId,orgUnitPath)").Pages(ctx, func(resp *directory.ChromeOsDevices) error {
+		for _, device := range resp.Chromeosdevices {
+			if strings.EqualFold(device.OrgUnitPath, orgUnitPath) {
+				deviceIds = append(deviceIds, device.DeviceId)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(deviceIds)
+
+	return deviceIds, nil
+}
+
+// diffOrgUnitMembers returns the desired members that are not current members and the current members
+// that are not desired, both sorted. Members are compared ignoring case.
+func diffOrgUnitMembers(current, desired []string) ([]string, []string) {
+	currentSet := map[string]bool{}
+	for _, member := range current {
+		currentSet[strings.ToLower(member)] = true
+	}
+
+	desiredSet := map[string]bool{}
+	add := []string{}
+	for _, member := range desired {
+		key := strings.ToLower(member)
+		if desiredSet[key] {
+			continue
+		}
+		desiredSet[key] = true
+
+		if !currentSet[key] {
+			add = append(add, member)
+		}
+	}
+
+	remove := []string{}
+	for _, member := range current {
+		if !desiredSet[strings.ToLower(member)] {
+			remove = append(remove, member)
+		}
+	}
+
+	sort.Strings(add)
+	sort.Strings(remove)
+
+	return add, remove
+}
+
+// matchConfiguredMembers returns the actual members, using the configured spelling of members that only
+// differ in case.
+func matchConfiguredMembers(configured, actual []string) []string {
+	spelling := map[string]string{}
+	for _, member := range configured {
+		spelling[strings.ToLower(member)] = member
+	}
+
+	result := make([]string, 0, len(actual))
+	for _, member := range actual {
+		if s, ok := spelling[strings.ToLower(member)]; ok {
+			member = s
+		}
+		result = append(result, member)
+	}
+
+	return result
+}
+
+// moveUsersToOrgUnit moves the users to the org unit with concurrent users.update calls.
+func moveUsersToOrgUnit(ctx context.Context, usersService *directory.UsersService, emails []string, orgUnitPath string, concurrency int) error {
+	return forEachConcurrently(ctx, len(emails), concurrency, func(ctx context.Context, i int) error {
+		log.Printf("[DEBUG] Moving User %q to OrgUnit %q", emails[i], orgUnitPath)
+
+		_, err := usersService.Update(emails[i], &directory.User{OrgUnitPath: orgUnitPath}).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("error moving user %s to %s: %w", emails[i], orgUnitPath, err)
+		}
+
+		return nil
+	})
+}
+
+// moveDevicesToOrgUnit moves the Chrome OS devices to the org unit in batches.
+func moveDevicesToOrgUnit(ctx context.Context, chromeosDevicesService *directory.ChromeosdevicesService, customer string, deviceIds []string, orgUnitPath string) error {
+	for start := 0; start < len(deviceIds); start += orgUnitMembersMaxDevicesPerMove {
+		batch := deviceIds[start:min(start+orgUnitMembersMaxDevicesPerMove, len(deviceIds))]
+		log.Printf("[DEBUG] Moving %d Chrome OS Devices to OrgUnit %q", len(batch), orgUnitPath)
+
+		err := chromeosDevicesService.MoveDevicesToOu(customer, orgUnitPath, &directory.ChromeOsMoveDevicesToOu{
+			DeviceIds: batch,
+		}).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("error moving devices %v to %s: %w", batch, orgUnitPath, err)
+		}
+	}
+
+	return nil
+}
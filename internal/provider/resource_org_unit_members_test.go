@@ -0,0 +1,227 @@
+package googleworkspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDiffOrgUnitMembers(t *testing.T) {
+	current := []string{"alice@example.com", "Bob@example.com", "dave@example.com"}
+	desired := []string{"carol@example.com", "bob@example.com", "Carol@example.com", "alice@example.com"}
+
+	add, remove := diffOrgUnitMembers(current, desired)
+
+	if want := []string{"carol@example.com"}; !reflect.DeepEqual(add, want) {
+		t.Errorf("expected add %v, got %v", want, add)
+	}
+	if want := []string{"dave@example.com"}; !reflect.DeepEqual(remove, want) {
+		t.Errorf("expected remove %v, got %v", want, remove)
+	}
+}
+
+func TestMatchConfiguredMembers(t *testing.T) {
+	got := matchConfiguredMembers([]string{"Alice@Example.com", "gone@example.com"},
+		[]string{"alice@example.com", "eve@example.com"})
+
+	if want := []string{"Alice@Example.com", "eve@example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestResourceOrgUnitMembersImport(t *testing.T) {
+	d := resourceOrgUnitMembers().TestResourceData()
+	d.SetId("/Sales/EMEA")
+
+	if _, err := resourceOrgUnitMembersImport(context.Background(), d, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := d.Get("org_unit_path").(string); got != "/Sales/EMEA" {
+		t.Errorf("expected org_unit_path /Sales/EMEA, got %q", got)
+	}
+
+	d.SetId("Sales")
+	if _, err := resourceOrgUnitMembersImport(context.Background(), d, nil); err == nil {
+		t.Errorf("expected an error for an id without a leading /")
+	}
+}
+
+func TestApplyOrgUnitMembers(t *testing.T) {
+	userOrgUnits := map[string]string{
+		"alice@example.com": "/Sales",
+		"bob@example.com":   "/Sales",
+		"carol@example.com": "/",
+		"dave@example.com":  "/Sales/EMEA",
+	}
+	deviceOrgUnits := map[string]string{}
+	var deviceMoves []string
+
+	client, _ := newFakeApiServer(t, "/admin/directory/v1", func(w http.ResponseWriter, r *http.Request, path string) {
+		switch {
+		case r.Method == http.MethodGet && path == "/users":
+			// like the API, the query also matches the users of sub-org units
+			var users []map[string]string
+			for email, ou := range userOrgUnits {
+				if strings.HasPrefix(ou, "/Sales") {
+					users = append(users, map[string]string{"primaryEmail": email, "orgUnitPath": ou})
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"users": users})
+		case r.Method == http.MethodPut && strings.HasPrefix(path, "/users/"):
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			userOrgUnits[strings.TrimPrefix(path, "/users/")] = body["orgUnitPath"]
+			fmt.Fprint(w, `{}`)
+		case r.Method == http.MethodGet && path == "/customer/my_customer/devices/chromeos":
+			var devices []map[string]string
+			for id, ou := range deviceOrgUnits {
+				devices = append(devices, map[string]string{"deviceId": id, "orgUnitPath": ou})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"chromeosdevices": devices})
+		case r.Method == http.MethodPost && path == "/customer/my_customer/devices/chromeos/moveDevicesToOu":
+			var body struct {
+				DeviceIds []string `json:"deviceIds"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			for _, id := range body.DeviceIds {
+				deviceOrgUnits[id] = r.URL.Query().Get("orgUnitPath")
+			}
+			deviceMoves = append(deviceMoves, fmt.Sprintf("%d to %s", len(body.DeviceIds), r.URL.Query().Get("orgUnitPath")))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "Resource Not Found"}}`)
+		}
+	})
+	client.Customer = "my_customer"
+
+	deviceIds := []interface{}{}
+	for i := 0; i < 60; i++ {
+		deviceId := fmt.Sprintf("device-%02d", i)
+		deviceIds = append(deviceIds, deviceId)
+		deviceOrgUnits[deviceId] = "/"
+	}
+	deviceOrgUnits["device-gone"] = "/Sales"
+
+	d := schema.TestResourceDataRaw(t, resourceOrgUnitMembers().Schema, map[string]interface{}{
+		"org_unit_path":          "/Sales",
+		"users":                  []interface{}{"bob@example.com", "carol@example.com"},
+		"manage_devices":         true,
+		"device_ids":             deviceIds,
+		"fallback_org_unit_path": "/Unassigned",
+	})
+
+	if diags := applyOrgUnitMembers(context.Background(), d, client, time.Minute); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	wantUsers := map[string]string{
+		"alice@example.com": "/Unassigned",
+		"bob@example.com":   "/Sales",
+		"carol@example.com": "/Sales",
+		"dave@example.com":  "/Sales/EMEA",
+	}
+	if !reflect.DeepEqual(userOrgUnits, wantUsers) {
+		t.Errorf("expected users in %v, got %v", wantUsers, userOrgUnits)
+	}
+
+	sort.Strings(deviceMoves)
+	if want := []string{"1 to /Unassigned", "10 to /Sales", "50 to /Sales"}; !reflect.DeepEqual(deviceMoves, want) {
+		t.Errorf("expected device moves %v, got %v", want, deviceMoves)
+	}
+}
+
+func TestApplyOrgUnitMembers_sameFallback(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceOrgUnitMembers().Schema, map[string]interface{}{
+		"org_unit_path":          "/Sales",
+		"fallback_org_unit_path": "/sales",
+	})
+
+	if diags := applyOrgUnitMembers(context.Background(), d, &apiClient{}, time.Minute); !diags.HasError() {
+		t.Errorf("expected an error for a fallback org unit that is the org unit")
+	}
+}
+
+func TestAccResourceOrgUnitMembers_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testUserVals := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"password":   acctest.RandString(10),
+		"ouName":     fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceOrgUnitMembers_basic(testUserVals, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_org_unit_members.test", "users.#", "1"),
+				),
+			},
+			{
+				ResourceName:            "googleworkspace_org_unit_members.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"fallback_org_unit_path", "concurrency"},
+			},
+			{
+				Config:      testAccResourceOrgUnitMembers_basic(testUserVals, `device_ids = ["unmanaged"]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("device_ids can only be set if manage_devices is true"),
+			},
+		},
+	})
+}
+
+func testAccResourceOrgUnitMembers_basic(testUserVals map[string]interface{}, devices string) string {
+	testUserVals["devices"] = devices
+
+	return Nprintf(`
+resource "googleworkspace_org_unit" "test" {
+  name                 = "%{ouName}"
+  parent_org_unit_path = "/"
+}
+
+resource "googleworkspace_user" "test" {
+  primary_email = "%{userEmail}@%{domainName}"
+  password      = "%{password}"
+
+  name {
+    family_name = "Scott"
+    given_name  = "Michael"
+  }
+
+  lifecycle {
+    ignore_changes = [org_unit_path]
+  }
+}
+
+resource "googleworkspace_org_unit_members" "test" {
+  org_unit_path = googleworkspace_org_unit.test.org_unit_path
+  users         = [googleworkspace_user.test.primary_email]
+  %{devices}
+}
+`, testUserVals)
+}
@@ -0,0 +1,622 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func resourceOrgUnitTree() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "OrgUnit Tree resource manages a hierarchy of Google Workspace OrgUnits as a whole. Changes are " +
+			"applied as a minimal set of creates, renames, re-parents and deletes, parents before children and " +
+			"children before parents for deletes. Org units that already exist at a configured path are adopted, " +
+			"and are only released, never deleted, when they are removed from the tree or the tree is destroyed. " +
+			"Org units that are not configured are left untouched. Org Unit Tree resides under the " +
+			"`https://www.googleapis.com/auth/admin.directory.orgunit` client scope, and the " +
+			"`https://www.googleapis.com/auth/admin.directory.user` client scope when `fallback_org_unit_path` is set.",
+
+		CreateContext: resourceOrgUnitTreeCreate,
+		ReadContext:   resourceOrgUnitTreeRead,
+		UpdateContext: resourceOrgUnitTreeUpdate,
+		DeleteContext: resourceOrgUnitTreeDelete,
+
+		CustomizeDiff: resourceOrgUnitTreeCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_units": {
+				Description: "The org units of the tree. The hierarchy is given by the paths, the parent of each org " +
+					"unit is either the root `/`, another org unit of the tree or an existing org unit.",
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Description: "The full path of the org unit, e.g. `/engineering/apps`.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"description": {
+							Description: "Description of the organizational unit.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"block_inheritance": {
+							Description: "Determines if a sub-organizational unit can inherit the settings of the parent organization.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+						"moved_from": {
+							Description: "The previous path of the org unit. If an org unit of the tree exists at this " +
+								"path, it is renamed and re-parented to `path` instead of being deleted and created " +
+								"again, keeping its users, devices and settings.",
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"fallback_org_unit_path": {
+				Description: "The full path of the org unit that the users of an org unit are moved to before it " +
+					"is deleted. If unset, deleting an org unit that contains users fails.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"org_unit_ids": {
+				Description: "The unique IDs of the org units of the tree, by path.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"adopted_org_unit_ids": {
+				Description: "The unique IDs of the org units of the tree that already existed when they were added " +
+					"to the tree, by path. These org units are released instead of deleted.",
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"last_operations": {
+				Description: "The operations performed by the last apply, in order, e.g. `rename /a/b to /a/c`.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+type orgUnitTreeNode struct {
+	Path             string
+	Description      string
+	BlockInheritance bool
+	MovedFrom        string
+}
+
+type orgUnitTreeOp struct {
+	// Action is one of create, adopt, update, move, delete or release
+	Action   string
+	Id       string
+	FromPath string
+	Node     orgUnitTreeNode
+}
+
+func (op orgUnitTreeOp) String() string {
+	switch op.Action {
+	case "move":
+		if path.Dir(op.FromPath) == path.Dir(op.Node.Path) {
+			return fmt.Sprintf("rename %s to %s", op.FromPath, op.Node.Path)
+		}
+		return fmt.Sprintf("move %s to %s", op.FromPath, op.Node.Path)
+	case "delete", "release":
+		return fmt.Sprintf("%s %s", op.Action, op.FromPath)
+	}
+
+	return fmt.Sprintf("%s %s", op.Action, op.Node.Path)
+}
+
+func resourceOrgUnitTreeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("org_units") {
+		return nil
+	}
+
+	for _, k := range []string{"org_unit_ids", "adopted_org_unit_ids", "last_operations"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceOrgUnitTreeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Creating OrgUnit Tree")
+
+	d.SetId(resource.UniqueId())
+
+	diags := applyOrgUnitTree(ctx, d, meta, expandOrgUnitTreeNodes(d.Get("org_units").(*schema.Set)), d.Timeout(schema.TimeoutCreate))
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished creating OrgUnit Tree %q", d.Id())
+
+	return resourceOrgUnitTreeRead(ctx, d, meta)
+}
+
+func resourceOrgUnitTreeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	log.Printf("[DEBUG] Getting OrgUnit Tree %q", d.Id())
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	orgUnitsService, diags := GetOrgUnitsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	orgUnits, err := listOrgUnitTreeNodes(ctx, orgUnitsService, client.Customer)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// moved_from only exists in the configuration, so it is carried over from the previous state
+	movedFrom := map[string]string{}
+	for _, node := range expandOrgUnitTreeNodes(d.Get("org_units").(*schema.Set)) {
+		movedFrom[node.Path] = node.MovedFrom
+	}
+
+	adopted := map[string]bool{}
+	for _, id := range d.Get("adopted_org_unit_ids").(map[string]interface{}) {
+		adopted[id.(string)] = true
+	}
+
+	ids := map[string]interface{}{}
+	adoptedIds := map[string]interface{}{}
+	var nodes []orgUnitTreeNode
+	for oldPath, id := range d.Get("org_unit_ids").(map[string]interface{}) {
+		node, ok := orgUnits[id.(string)]
+		if !ok {
+			log.Printf("[WARN] OrgUnit %q (%s) of OrgUnit Tree %q is gone", oldPath, id, d.Id())
+			continue
+		}
+
+		node.MovedFrom = movedFrom[oldPath]
+		nodes = append(nodes, node)
+		ids[node.Path] = id
+		if adopted[id.(string)] {
+			adoptedIds[node.Path] = id
+		}
+	}
+
+	if err := d.Set("org_units", flattenOrgUnitTreeNodes(nodes)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("org_unit_ids", ids)
+	d.Set("adopted_org_unit_ids", adoptedIds)
+
+	log.Printf("[DEBUG] Finished getting OrgUnit Tree %q", d.Id())
+
+	return diags
+}
+
+func resourceOrgUnitTreeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Updating OrgUnit Tree %q", d.Id())
+
+	diags := applyOrgUnitTree(ctx, d, meta, expandOrgUnitTreeNodes(d.Get("org_units").(*schema.Set)), d.Timeout(schema.TimeoutUpdate))
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished updating OrgUnit Tree %q", d.Id())
+
+	return resourceOrgUnitTreeRead(ctx, d, meta)
+}
+
+func resourceOrgUnitTreeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Deleting OrgUnit Tree %q", d.Id())
+
+	diags := applyOrgUnitTree(ctx, d, meta, nil, d.Timeout(schema.TimeoutDelete))
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished deleting OrgUnit Tree %q", d.Id())
+
+	return diags
+}
+
+// applyOrgUnitTree plans and applies the operations that turn the org units tracked in org_unit_ids into
+// the desired nodes, recording the org units in org_unit_ids as they are created, adopted, deleted and
+// released. Adopted org units are also recorded in adopted_org_unit_ids, so that they are never deleted.
+func applyOrgUnitTree(ctx context.Context, d *schema.ResourceData, meta interface{}, desired []orgUnitTreeNode, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	orgUnitsService, diags := GetOrgUnitsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	orgUnits, err := listOrgUnitTreeNodes(ctx, orgUnitsService, client.Customer)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the paths of the tracked org units, by id, recorded as the operations are applied so that
+	// a partially applied tree is still tracked
+	trackedPaths := map[string]string{}
+	var tracked []string
+	for p, id := range d.Get("org_unit_ids").(map[string]interface{}) {
+		trackedPaths[id.(string)] = p
+		tracked = append(tracked, id.(string))
+	}
+
+	adopted := map[string]bool{}
+	for _, id := range d.Get("adopted_org_unit_ids").(map[string]interface{}) {
+		adopted[id.(string)] = true
+	}
+
+	ops, err := planOrgUnitTree(orgUnits, tracked, adopted, desired)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	defer func() {
+		ids := map[string]interface{}{}
+		adoptedIds := map[string]interface{}{}
+		for id, p := range trackedPaths {
+			ids[p] = id
+			if adopted[id] {
+				adoptedIds[p] = id
+			}
+		}
+		d.Set("org_unit_ids", ids)
+		d.Set("adopted_org_unit_ids", adoptedIds)
+	}()
+
+	var performed []string
+	for i, op := range ops {
+		log.Printf("[DEBUG] OrgUnit Tree %q: %s", d.Id(), op)
+
+		switch op.Action {
+		case "create":
+			orgUnit, err := orgUnitsService.Insert(client.Customer, expandOrgUnitTreeNode(op.Node)).Do()
+			if err != nil {
+				return diag.Errorf("error performing %q: %s", op, err)
+			}
+			ops[i].Id = orgUnit.OrgUnitId
+			trackedPaths[orgUnit.OrgUnitId] = op.Node.Path
+		case "adopt", "update", "move":
+			current := orgUnits[op.Id]
+			if op.Action != "adopt" || current.Description != op.Node.Description ||
+				current.BlockInheritance != op.Node.BlockInheritance {
+				_, err := orgUnitsService.Update(client.Customer, op.Id, expandOrgUnitTreeNode(op.Node)).Do()
+				if err != nil {
+					return diag.Errorf("error performing %q: %s", op, err)
+				}
+			}
+			trackedPaths[op.Id] = op.Node.Path
+			if op.Action == "adopt" {
+				adopted[op.Id] = true
+			}
+		case "delete":
+			if fallback := d.Get("fallback_org_unit_path").(string); fallback != "" {
+				diags = moveOrgUnitUsers(ctx, directoryService, client.Customer, op.FromPath, fallback)
+				if diags.HasError() {
+					return diags
+				}
+			}
+
+			err := orgUnitsService.Delete(client.Customer, op.Id).Do()
+			if err != nil && !isNotFound(err) {
+				return diag.Errorf("error performing %q: %s", op, err)
+			}
+			delete(trackedPaths, op.Id)
+		case "release":
+			delete(trackedPaths, op.Id)
+			delete(adopted, op.Id)
+		}
+
+		performed = append(performed, op.String())
+		d.Set("last_operations", performed)
+
+		if op.Action == "delete" || op.Action == "release" {
+			continue
+		}
+
+		// children can only be created once their parent is consistent
+		if err := waitForOrgUnitTreeNode(ctx, orgUnitsService, client.Customer, ops[i].Id, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.Set("last_operations", performed)
+
+	return diags
+}
+
+// planOrgUnitTree returns the operations turning the tracked org units into the desired nodes. orgUnits are
+// all of the customer's org units by ID. Moves and renames are applied to the descendants of the moved org
+// units. Creates, adoptions, updates and moves are ordered parents first, and deletes children first. The
+// adopted org units that are no longer desired are released instead of deleted.
+func planOrgUnitTree(orgUnits map[string]orgUnitTreeNode, tracked []string, adopted map[string]bool, desired []orgUnitTreeNode) ([]orgUnitTreeOp, error) {
+	pathToId := map[string]string{}
+	for id, node := range orgUnits {
+		pathToId[strings.ToLower(node.Path)] = id
+	}
+
+	desiredByPath := map[string]orgUnitTreeNode{}
+	for _, node := range desired {
+		if err := validateOrgUnitTreePath(node.Path); err != nil {
+			return nil, err
+		}
+
+		key := strings.ToLower(node.Path)
+		if _, ok := desiredByPath[key]; ok {
+			return nil, fmt.Errorf("duplicate org unit path %s", node.Path)
+		}
+		desiredByPath[key] = node
+	}
+
+	// explicit moves, by the id of the moved org unit
+	moves := map[string]string{}
+	for _, node := range desired {
+		if node.MovedFrom == "" {
+			continue
+		}
+
+		id, ok := pathToId[strings.ToLower(node.MovedFrom)]
+		if !ok {
+			continue
+		}
+		if _, ok := pathToId[strings.ToLower(node.Path)]; ok {
+			// the org unit was already moved, or the path is taken
+			continue
+		}
+		moves[id] = node.Path
+	}
+
+	// the path of each tracked org unit once its nearest moved ancestor, or itself, is moved
+	newPath := func(id string) string {
+		p := orgUnits[id].Path
+		for ancestor := p; ancestor != "/" && ancestor != "."; ancestor = path.Dir(ancestor) {
+			if to, ok := moves[pathToId[strings.ToLower(ancestor)]]; ok {
+				return to + p[len(ancestor):]
+			}
+		}
+
+		return p
+	}
+
+	var ops, deletes []orgUnitTreeOp
+	kept := map[string]bool{}
+	for _, id := range tracked {
+		current, ok := orgUnits[id]
+		if !ok {
+			continue
+		}
+
+		to := newPath(id)
+		node, ok := desiredByPath[strings.ToLower(to)]
+		if !ok {
+			action := "delete"
+			if adopted[id] {
+				action = "release"
+			}
+			deletes = append(deletes, orgUnitTreeOp{Action: action, Id: id, FromPath: to, Node: current})
+			continue
+		}
+		kept[strings.ToLower(to)] = true
+
+		if _, moved := moves[id]; moved {
+			ops = append(ops, orgUnitTreeOp{Action: "move", Id: id, FromPath: current.Path, Node: node})
+		} else if node.Description != current.Description || node.BlockInheritance != current.BlockInheritance {
+			ops = append(ops, orgUnitTreeOp{Action: "update", Id: id, FromPath: current.Path, Node: node})
+		}
+	}
+
+	for key, node := range desiredByPath {
+		if kept[key] {
+			continue
+		}
+
+		if id, ok := pathToId[key]; ok {
+			ops = append(ops, orgUnitTreeOp{Action: "adopt", Id: id, FromPath: orgUnits[id].Path, Node: node})
+			continue
+		}
+
+		// tracked org units that are moved are kept above, so this is an org unit moved into the tree
+		if movedId, ok := pathToId[strings.ToLower(node.MovedFrom)]; ok && moves[movedId] == node.Path {
+			ops = append(ops, orgUnitTreeOp{Action: "move", Id: movedId, FromPath: node.MovedFrom, Node: node})
+			continue
+		}
+
+		ops = append(ops, orgUnitTreeOp{Action: "create", Node: node})
+	}
+
+	depth := func(p string) int {
+		return strings.Count(p, "/")
+	}
+
+	sort.SliceStable(ops, func(i, j int) bool {
+		if depth(ops[i].Node.Path) != depth(ops[j].Node.Path) {
+			return depth(ops[i].Node.Path) < depth(ops[j].Node.Path)
+		}
+		return ops[i].Node.Path < ops[j].Node.Path
+	})
+
+	sort.SliceStable(deletes, func(i, j int) bool {
+		if depth(deletes[i].FromPath) != depth(deletes[j].FromPath) {
+			return depth(deletes[i].FromPath) > depth(deletes[j].FromPath)
+		}
+		return deletes[i].FromPath < deletes[j].FromPath
+	})
+
+	return append(ops, deletes...), nil
+}
+
+func validateOrgUnitTreePath(p string) error {
+	if !strings.HasPrefix(p, "/") || p == "/" || strings.HasSuffix(p, "/") || strings.Contains(p, "//") {
+		return fmt.Errorf("org unit path %q must start with a / and must not be the root or end with a /", p)
+	}
+
+	return nil
+}
+
+func expandOrgUnitTreeNodes(set *schema.Set) []orgUnitTreeNode {
+	var nodes []orgUnitTreeNode
+	for _, v := range set.List() {
+		m := v.(map[string]interface{})
+		nodes = append(nodes, orgUnitTreeNode{
+			Path:             m["path"].(string),
+			Description:      m["description"].(string),
+			BlockInheritance: m["block_inheritance"].(bool),
+			MovedFrom:        m["moved_from"].(string),
+		})
+	}
+
+	return nodes
+}
+
+func flattenOrgUnitTreeNodes(nodes []orgUnitTreeNode) []interface{} {
+	var result []interface{}
+	for _, node := range nodes {
+		result = append(result, map[string]interface{}{
+			"path":              node.Path,
+			"description":       node.Description,
+			"block_inheritance": node.BlockInheritance,
+			"moved_from":        node.MovedFrom,
+		})
+	}
+
+	return result
+}
+
+func expandOrgUnitTreeNode(node orgUnitTreeNode) *directory.OrgUnit {
+	return &directory.OrgUnit{
+		Name:              path.Base(node.Path),
+		ParentOrgUnitPath: path.Dir(node.Path),
+		Description:       node.Description,
+		BlockInheritance:  node.BlockInheritance,
+		ForceSendFields:   []string{"Description", "BlockInheritance"},
+	}
+}
+
+// listOrgUnitTreeNodes returns all of the customer's org units, by ID.
+func listOrgUnitTreeNodes(ctx context.Context, orgUnitsService *directory.OrgunitsService, customer string) (map[string]orgUnitTreeNode, error) {
+	orgUnits, err := orgUnitsService.List(customer).Type("all").Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]orgUnitTreeNode{}
+	for _, orgUnit := range orgUnits.OrganizationUnits {
+		result[orgUnit.OrgUnitId] = orgUnitTreeNode{
+			Path:             orgUnit.OrgUnitPath,
+			Description:      orgUnit.Description,
+			BlockInheritance: orgUnit.BlockInheritance,
+		}
+	}
+
+	return result, nil
+}
+
+// waitForOrgUnitTreeNode waits for the org unit to be consistent.
+func waitForOrgUnitTreeNode(ctx context.Context, orgUnitsService *directory.OrgunitsService, customer, id string, timeout time.Duration) error {
+	cc := consistencyCheck{
+		resourceType: "org unit",
+		timeout:      timeout,
+	}
+
+	return retryTimeDuration(ctx, timeout, func() error {
+		var retryErr error
+
+		if cc.reachedConsistency(1) {
+			return nil
+		}
+
+		newOrgUnit, retryErr := orgUnitsService.Get(customer, id).IfNoneMatch(cc.lastEtag).Do()
+		if googleapi.IsNotModified(retryErr) {
+			cc.currConsistent += 1
+		} else if isNotFound(retryErr) {
+			// org unit was not found yet therefore setting currConsistent back to null value
+			cc.currConsistent = 0
+		} else if retryErr != nil {
+			return fmt.Errorf("unexpected error during retries of %s: %s", cc.resourceType, retryErr)
+		} else {
+			cc.handleNewEtag(newOrgUnit.Etag)
+		}
+
+		return fmt.Errorf("timed out while waiting for %s to be updated", cc.resourceType)
+	})
+}
+
+// moveOrgUnitUsers moves the users directly in the org unit at orgUnitPath to the fallback org unit.
+func moveOrgUnitUsers(ctx context.Context, directoryService *directory.Service, customer, orgUnitPath, fallback string) diag.Diagnostics {
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	emails, err := listOrgUnitUserEmails(ctx, usersService, customer, orgUnitPath)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := moveUsersToOrgUnit(ctx, usersService, emails, fallback, 1); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// orgUnitPathQuery returns a users.list query matching the users of the org unit and its sub-org units.
+func orgUnitPathQuery(orgUnitPath string) string {
+	return fmt.Sprintf("orgUnitPath='%s'", strings.ReplaceAll(orgUnitPath, "'", "\\'"))
+}
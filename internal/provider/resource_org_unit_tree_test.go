@@ -0,0 +1,200 @@
+package googleworkspace
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testOrgUnitTreeOps(t *testing.T, orgUnits map[string]orgUnitTreeNode, tracked []string, adopted map[string]bool, desired []orgUnitTreeNode) []string {
+	ops, err := planOrgUnitTree(orgUnits, tracked, adopted, desired)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	result := []string{}
+	for _, op := range ops {
+		result = append(result, op.String())
+	}
+
+	return result
+}
+
+func TestPlanOrgUnitTree_create(t *testing.T) {
+	desired := []orgUnitTreeNode{
+		{Path: "/engineering/apps"},
+		{Path: "/sales"},
+		{Path: "/engineering"},
+	}
+
+	expected := []string{"create /engineering", "create /sales", "create /engineering/apps"}
+	if actual := testOrgUnitTreeOps(t, nil, nil, nil, desired); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestPlanOrgUnitTree_moves(t *testing.T) {
+	orgUnits := map[string]orgUnitTreeNode{
+		"id:1": {Path: "/engineering"},
+		"id:2": {Path: "/engineering/apps"},
+		"id:3": {Path: "/engineering/apps/mobile", Description: "Mobile"},
+		"id:4": {Path: "/sales"},
+		"id:5": {Path: "/sales/emea"},
+		"id:6": {Path: "/unmanaged"},
+	}
+	tracked := []string{"id:1", "id:2", "id:3", "id:4", "id:5"}
+
+	desired := []orgUnitTreeNode{
+		// renamed, the children follow
+		{Path: "/product", MovedFrom: "/engineering"},
+		{Path: "/product/apps"},
+		{Path: "/product/apps/mobile", Description: "iOS and Android"},
+		// re-parented under a new org unit
+		{Path: "/regions"},
+		{Path: "/regions/emea", MovedFrom: "/sales/emea"},
+		// adopted
+		{Path: "/unmanaged", BlockInheritance: true},
+	}
+
+	expected := []string{
+		"rename /engineering to /product",
+		"create /regions",
+		"adopt /unmanaged",
+		"move /sales/emea to /regions/emea",
+		"update /product/apps/mobile",
+		"delete /sales",
+	}
+	if actual := testOrgUnitTreeOps(t, orgUnits, tracked, nil, desired); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestPlanOrgUnitTree_delete(t *testing.T) {
+	orgUnits := map[string]orgUnitTreeNode{
+		"id:1": {Path: "/engineering"},
+		"id:2": {Path: "/engineering/apps"},
+		"id:3": {Path: "/engineering/apps/mobile"},
+		"id:4": {Path: "/sales"},
+	}
+
+	expected := []string{"delete /engineering/apps/mobile", "delete /engineering/apps", "delete /engineering"}
+	actual := testOrgUnitTreeOps(t, orgUnits, []string{"id:1", "id:2", "id:3"}, nil, nil)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	// org units that were already moved are left as they are
+	desired := []orgUnitTreeNode{{Path: "/engineering", MovedFrom: "/eng"}, {Path: "/sales"}}
+	if actual := testOrgUnitTreeOps(t, orgUnits, []string{"id:1", "id:4"}, nil, desired); len(actual) != 0 {
+		t.Errorf("expected no operations, got %v", actual)
+	}
+}
+
+func TestPlanOrgUnitTree_release(t *testing.T) {
+	orgUnits := map[string]orgUnitTreeNode{
+		"id:1": {Path: "/engineering"},
+		"id:2": {Path: "/engineering/apps"},
+		"id:3": {Path: "/sales"},
+	}
+	tracked := []string{"id:1", "id:2", "id:3"}
+	adopted := map[string]bool{"id:1": true, "id:3": true}
+
+	// adopted org units are released instead of deleted
+	expected := []string{"delete /engineering/apps", "release /engineering", "release /sales"}
+	if actual := testOrgUnitTreeOps(t, orgUnits, tracked, adopted, nil); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	expected = []string{"release /sales"}
+	desired := []orgUnitTreeNode{{Path: "/engineering"}, {Path: "/engineering/apps"}}
+	if actual := testOrgUnitTreeOps(t, orgUnits, tracked, adopted, desired); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestPlanOrgUnitTree_invalid(t *testing.T) {
+	cases := map[string][]orgUnitTreeNode{
+		"root":      {{Path: "/"}},
+		"relative":  {{Path: "engineering"}},
+		"trailing":  {{Path: "/engineering/"}},
+		"duplicate": {{Path: "/engineering"}, {Path: "/Engineering"}},
+	}
+
+	for name, desired := range cases {
+		if _, err := planOrgUnitTree(nil, nil, nil, desired); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestAccResourceOrgUnitTree_basic(t *testing.T) {
+	t.Parallel()
+
+	if os.Getenv("GOOGLEWORKSPACE_DOMAIN") == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testOrgUnitValues := map[string]interface{}{
+		"name": fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceOrgUnitTree_basic(testOrgUnitValues),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_org_unit_tree.tree", "org_units.#", "3"),
+					resource.TestCheckResourceAttr("googleworkspace_org_unit_tree.tree", "org_unit_ids.%", "3"),
+				),
+			},
+			{
+				Config: testAccResourceOrgUnitTree_moved(testOrgUnitValues),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_org_unit_tree.tree", "org_units.#", "2"),
+					resource.TestCheckResourceAttr("googleworkspace_org_unit_tree.tree", "last_operations.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceOrgUnitTree_basic(testOrgUnitValues map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_org_unit_tree" "tree" {
+  org_units {
+    path = "/%{name}"
+  }
+
+  org_units {
+    path        = "/%{name}/apps"
+    description = "Apps"
+  }
+
+  org_units {
+    path = "/%{name}/old"
+  }
+}
+`, testOrgUnitValues)
+}
+
+func testAccResourceOrgUnitTree_moved(testOrgUnitValues map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_org_unit_tree" "tree" {
+  org_units {
+    path       = "/%{name}-renamed"
+    moved_from = "/%{name}"
+  }
+
+  org_units {
+    path        = "/%{name}-renamed/apps"
+    description = "Apps"
+  }
+}
+`, testOrgUnitValues)
+}
@@ -2,8 +2,11 @@ package googleworkspace
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -14,7 +17,9 @@ import (
 
 func resourceRole() *schema.Resource {
 	return &schema.Resource{
-		Description: "Role resource in the Terraform Googleworkspace provider. Role resides " +
+		Description: "Role resource in the Terraform Googleworkspace provider. Privileges are validated against " +
+			"the privileges of the customer when planning, and child privileges granted implicitly alongside " +
+			"their parent privilege are not reported as differences. Role resides " +
 			"under the `https://www.googleapis.com/auth/admin.directory.rolemanagement` client scope.",
 
 		CreateContext: resourceRoleCreate,
@@ -22,6 +27,8 @@ func resourceRole() *schema.Resource {
 		UpdateContext: resourceRoleUpdate,
 		DeleteContext: resourceRoleDelete,
 
+		CustomizeDiff: resourceRoleCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -61,6 +68,13 @@ func resourceRole() *schema.Resource {
 					},
 				},
 			},
+			"expand_children": {
+				Description: "If `true`, the child privileges of the privileges in " +
+					"`privileges` are granted as well, without listing them in `privileges`.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"is_system_role": {
 				Description: "Returns true if this is a pre-defined system role.",
 				Type:        schema.TypeBool,
@@ -95,7 +109,12 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, meta interf
 
 	log.Printf("[DEBUG] Creating Role %q", d.Get("name").(string))
 
-	role, err := rolesService.Insert(client.Customer, getRole(d)).Do()
+	roleObj, diags := expandRole(d, client)
+	if diags.HasError() {
+		return diags
+	}
+
+	role, err := rolesService.Insert(client.Customer, roleObj).Do()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -121,7 +140,12 @@ func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 
 	log.Printf("[DEBUG] Updating Role %q", d.Id())
 
-	_, err := rolesService.Update(client.Customer, d.Id(), getRole(d)).Do()
+	roleObj, diags := expandRole(d, client)
+	if diags.HasError() {
+		return diags
+	}
+
+	_, err := rolesService.Update(client.Customer, d.Id(), roleObj).Do()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -154,6 +178,18 @@ func resourceRoleRead(ctx context.Context, d *schema.ResourceData, meta interfac
 		return diag.Errorf("No Role was returned for %s.", d.Id())
 	}
 
+	index, diags := getPrivilegeIndex(client)
+	if diags.HasError() {
+		return diags
+	}
+
+	configured := map[string]bool{}
+	for _, p := range d.Get("privileges").(*schema.Set).List() {
+		priv := p.(map[string]interface{})
+		configured[privilegeKey(priv["privilege_name"].(string), priv["service_id"].(string))] = true
+	}
+	role.RolePrivileges = pruneImpliedPrivileges(role.RolePrivileges, configured, index)
+
 	if diags := setRole(d, role); diags.HasError() {
 		return diags
 	}
@@ -212,6 +248,130 @@ func getRole(d *schema.ResourceData) *directory.Role {
 	return role
 }
 
+// expandRole returns the role to send to the API, with the child privileges added if expand_children is set.
+func expandRole(d *schema.ResourceData, client *apiClient) (*directory.Role, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	role := getRole(d)
+	if !d.Get("expand_children").(bool) {
+		return role, diags
+	}
+
+	index, diags := getPrivilegeIndex(client)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	role.RolePrivileges = expandChildPrivileges(role.RolePrivileges, index)
+
+	return role, diags
+}
+
+// resourceRoleCustomizeDiff validates the privileges when planning, so that typos don't fail the apply.
+func resourceRoleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("privileges") {
+		return nil
+	}
+
+	index, diags := getPrivilegeIndex(meta.(*apiClient))
+	if diags.HasError() {
+		return fmt.Errorf("%s", diags[0].Summary)
+	}
+
+	var privileges []*directory.RoleRolePrivileges
+	for _, p := range d.Get("privileges").(*schema.Set).List() {
+		priv := p.(map[string]interface{})
+		privileges = append(privileges, &directory.RoleRolePrivileges{
+			PrivilegeName: priv["privilege_name"].(string),
+			ServiceId:     priv["service_id"].(string),
+		})
+	}
+
+	return validateRolePrivileges(privileges, index)
+}
+
+// validateRolePrivileges returns an error listing the privileges that don't exist, suggesting the
+// service IDs a misplaced privilege name exists for.
+func validateRolePrivileges(privileges []*directory.RoleRolePrivileges, index *privilegeIndex) error {
+	var invalid []string
+	for _, priv := range privileges {
+		if priv.ServiceId == "" || priv.PrivilegeName == "" {
+			// not known yet
+			continue
+		}
+		if index.known[privilegeKey(priv.PrivilegeName, priv.ServiceId)] {
+			continue
+		}
+
+		msg := fmt.Sprintf("%s (service_id %s)", priv.PrivilegeName, priv.ServiceId)
+		if serviceIds := index.serviceIds[priv.PrivilegeName]; len(serviceIds) > 0 {
+			msg += fmt.Sprintf(", it exists for service_id %s", strings.Join(serviceIds, ", "))
+		}
+		invalid = append(invalid, msg)
+	}
+
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return fmt.Errorf("unknown privileges, see the googleworkspace_privileges data source for the "+
+			"available privileges: %s", strings.Join(invalid, "; "))
+	}
+
+	return nil
+}
+
+// expandChildPrivileges returns the privileges together with all of their descendants, without duplicates.
+func expandChildPrivileges(privileges []*directory.RoleRolePrivileges, index *privilegeIndex) []*directory.RoleRolePrivileges {
+	seen := map[string]bool{}
+	var result []*directory.RoleRolePrivileges
+
+	add := func(name, serviceId string) {
+		key := privilegeKey(name, serviceId)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		result = append(result, &directory.RoleRolePrivileges{PrivilegeName: name, ServiceId: serviceId})
+	}
+
+	for _, priv := range privileges {
+		add(priv.PrivilegeName, priv.ServiceId)
+	}
+	for _, priv := range privileges {
+		for _, d := range index.descendants[privilegeKey(priv.PrivilegeName, priv.ServiceId)] {
+			child := d.(map[string]interface{})
+			add(child["privilege_name"].(string), child["service_id"].(string))
+		}
+	}
+
+	return result
+}
+
+// pruneImpliedPrivileges removes the privileges that aren't configured and descend from another
+// privilege of the role, as they're added by expand_children or implied by the API.
+func pruneImpliedPrivileges(privileges []*directory.RoleRolePrivileges, configured map[string]bool, index *privilegeIndex) []*directory.RoleRolePrivileges {
+	var result []*directory.RoleRolePrivileges
+	for _, priv := range privileges {
+		key := privilegeKey(priv.PrivilegeName, priv.ServiceId)
+
+		implied := false
+		if !configured[key] {
+			for _, other := range privileges {
+				otherKey := privilegeKey(other.PrivilegeName, other.ServiceId)
+				if otherKey != key && index.isDescendant(key, otherKey) {
+					implied = true
+					break
+				}
+			}
+		}
+
+		if !implied {
+			result = append(result, priv)
+		}
+	}
+
+	return result
+}
+
 func setRole(d *schema.ResourceData, role *directory.Role) diag.Diagnostics {
 	var diags diag.Diagnostics
 
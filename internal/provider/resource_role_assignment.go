@@ -2,6 +2,7 @@ package googleworkspace
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strconv"
 	"strings"
@@ -15,14 +16,21 @@ import (
 
 func resourceRoleAssignment() *schema.Resource {
 	return &schema.Resource{
-		Description: "Role Assignment resource in the Terraform Googleworkspace provider. Role Assignment resides " +
-			"under the `https://www.googleapis.com/auth/admin.directory.rolemanagement` client scope.",
+		Description: "Role Assignment resource in the Terraform Googleworkspace provider. A role can be assigned " +
+			"to a user, a group or a service account. The API doesn't support updating role assignments, so " +
+			"changing the role, assignee, scope or condition replaces the assignment. Role Assignment resides " +
+			"under the `https://www.googleapis.com/auth/admin.directory.rolemanagement` client scope, resolving " +
+			"email addresses additionally requires the `https://www.googleapis.com/auth/admin.directory.user.readonly` " +
+			"and `https://www.googleapis.com/auth/admin.directory.group.readonly` client scopes.",
 		CreateContext: resourceRolesAssignmentCreate,
 		ReadContext:   resourceRoleAssignmentRead,
+		UpdateContext: resourceRoleAssignmentUpdate,
 		DeleteContext: resourceRoleAssignmentDelete,
 
+		CustomizeDiff: resourceRoleAssignmentCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceRoleAssignmentImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -43,11 +51,39 @@ func resourceRoleAssignment() *schema.Resource {
 				Computed:    true,
 			},
 			"assigned_to": {
-				Description: "The unique ID of the user this role is assigned to.",
+				Description: "The unique ID or email address of the user or group, or the unique ID of the " +
+					"service account, this role is assigned to. Email addresses are resolved to IDs when planning, " +
+					"the assignment is only replaced if the resolved ID changes.",
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"assignee_type": {
+				Description: "The type of the assignee, used to resolve an email address in `assigned_to`. If " +
+					"unset, the email address is looked up as a user, then as a group. Valid values are :" +
+					"\n\t- `USER`" +
+					"\n\t- `GROUP`" +
+					"\n\t- `SERVICE_ACCOUNT`",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"USER", "GROUP", "SERVICE_ACCOUNT"}, false)),
+			},
+			"assignee_id": {
+				Description: "The unique ID of the user, group or service account this role is assigned to.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Computed:    true,
 				ForceNew:    true,
 			},
+			"condition": {
+				Description: "The condition associated with this role assignment, e.g. to restrict the assignment " +
+					"to security groups. The condition strings have to be verbatim and only work with some " +
+					"pre-built administrator roles, see the " +
+					"[API documentation](https://developers.google.com/admin-sdk/directory/reference/rest/v1/roleAssignments) " +
+					"for the supported conditions.",
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
 			"scope_type": {
 				Description: "The scope in which this role is assigned. Valid values are :" +
 					"\n\t- `CUSTOMER`" +
@@ -82,13 +118,19 @@ func resourceRolesAssignmentCreate(ctx context.Context, d *schema.ResourceData,
 
 	assignedTo := d.Get("assigned_to").(string)
 	roleId := d.Get("role_id").(string)
-	log.Printf("[DEBUG] Creating RoleAssignment user:%s, role:%s", assignedTo, roleId)
+	log.Printf("[DEBUG] Creating RoleAssignment assignee:%s, role:%s", assignedTo, roleId)
 
 	directoryService, diags := client.NewDirectoryService()
 	if diags.HasError() {
 		return diags
 	}
 
+	// assigned_to may not have been known when planning
+	assigneeId, assigneeType, err := resolveRoleAssignee(directoryService, assignedTo, d.Get("assignee_type").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	roleAssignmentsService, diags := GetRoleAssignmentsService(directoryService)
 	if diags.HasError() {
 		return diags
@@ -112,10 +154,11 @@ func resourceRolesAssignmentCreate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	ra := &directory.RoleAssignment{
-		AssignedTo: assignedTo,
+		AssignedTo: assigneeId,
 		RoleId:     roleIdInt64,
 		ScopeType:  scopeType,
 		OrgUnitId:  orgUnitId,
+		Condition:  d.Get("condition").(string),
 	}
 
 	ra, err = roleAssignmentsService.Insert(client.Customer, ra).Do()
@@ -124,8 +167,11 @@ func resourceRolesAssignmentCreate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	d.SetId(strconv.FormatInt(ra.RoleAssignmentId, 10))
+	if assigneeType != "" {
+		d.Set("assignee_type", assigneeType)
+	}
 
-	log.Printf("[DEBUG] Finished creating RoleAssignment user:%s, role:%s", assignedTo, roleId)
+	log.Printf("[DEBUG] Finished creating RoleAssignment assignee:%s, role:%s", assignedTo, roleId)
 
 	return resourceRoleAssignmentRead(ctx, d, meta)
 }
@@ -158,15 +204,31 @@ func resourceRoleAssignmentRead(ctx context.Context, d *schema.ResourceData, met
 	d.SetId(strconv.FormatInt(ra.RoleAssignmentId, 10))
 	d.Set("role_id", strconv.FormatInt(ra.RoleId, 10))
 	d.Set("etag", ra.Etag)
-	d.Set("assigned_to", ra.AssignedTo)
+	d.Set("assignee_id", ra.AssignedTo)
+	// an email address is kept as configured, the resolved ID is tracked in assignee_id
+	if !isEmail(d.Get("assigned_to").(string)) {
+		d.Set("assigned_to", ra.AssignedTo)
+	}
+	// the API reports service accounts as users
+	if !(ra.AssigneeType == "user" && d.Get("assignee_type").(string) == "SERVICE_ACCOUNT") {
+		d.Set("assignee_type", strings.ToUpper(ra.AssigneeType))
+	}
 	d.Set("scope_type", ra.ScopeType)
 	d.Set("org_unit_id", ra.OrgUnitId)
+	d.Set("condition", ra.Condition)
 
 	log.Printf("[DEBUG] Finished getting RoleAssignment %q", d.Id())
 
 	return diags
 }
 
+// Only assigned_to and assignee_type can change without a new assignee_id, so there is nothing to update
+func resourceRoleAssignmentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Updating RoleAssignment %q", d.Id())
+
+	return resourceRoleAssignmentRead(ctx, d, meta)
+}
+
 func resourceRoleAssignmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -193,3 +255,147 @@ func resourceRoleAssignmentDelete(ctx context.Context, d *schema.ResourceData, m
 
 	return diags
 }
+
+// resourceRoleAssignmentCustomizeDiff resolves the email address of the assignee, so that the
+// assignment is replaced when the address now belongs to another user or group.
+func resourceRoleAssignmentCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("assigned_to") {
+		return d.SetNewComputed("assignee_id")
+	}
+
+	assignedTo := d.Get("assigned_to").(string)
+	assigneeId := assignedTo
+
+	if isEmail(assignedTo) {
+		client := meta.(*apiClient)
+
+		directoryService, diags := client.NewDirectoryService()
+		if diags.HasError() {
+			return fmt.Errorf("%s", diags[0].Summary)
+		}
+
+		var err error
+		assigneeId, _, err = resolveRoleAssignee(directoryService, assignedTo, d.Get("assignee_type").(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	if assigneeId != d.Get("assignee_id").(string) {
+		return d.SetNew("assignee_id", assigneeId)
+	}
+
+	return nil
+}
+
+func resourceRoleAssignmentImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// id is either the role assignment ID or of format "<role_id>/<assignee>/<scope>"
+	if !strings.Contains(d.Id(), "/") {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("role assignment id (%s) is not of the correct format "+
+			"(<role_assignment_id> or <role_id>/<assignee>/<CUSTOMER or org_unit_id>)", d.Id())
+	}
+	roleId, assignee, scope := parts[0], parts[1], parts[2]
+
+	client := meta.(*apiClient)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s", diags[0].Summary)
+	}
+
+	roleAssignmentsService, diags := GetRoleAssignmentsService(directoryService)
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s", diags[0].Summary)
+	}
+
+	assigneeId, assigneeType, err := resolveRoleAssignee(directoryService, assignee, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []string
+	err = roleAssignmentsService.List(client.Customer).RoleId(roleId).Pages(ctx, func(resp *directory.RoleAssignments) error {
+		for _, ra := range resp.Items {
+			if ra.AssignedTo == assigneeId && roleAssignmentInScope(ra, scope) {
+				matches = append(matches, strconv.FormatInt(ra.RoleAssignmentId, 10))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no role assignment of role %s to %s in scope %s was found", roleId, assignee, scope)
+	}
+	if len(matches) > 1 {
+		return nil, fmt.Errorf("role %s is assigned to %s in scope %s more than once, e.g. with different "+
+			"conditions, import one of the role assignment IDs %s instead", roleId, assignee, scope, strings.Join(matches, ", "))
+	}
+
+	d.SetId(matches[0])
+	d.Set("assigned_to", assignee)
+	if assigneeType != "" {
+		d.Set("assignee_type", assigneeType)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// roleAssignmentInScope returns whether the role assignment has the scope, either CUSTOMER or an org unit ID.
+func roleAssignmentInScope(ra *directory.RoleAssignment, scope string) bool {
+	if strings.EqualFold(scope, "CUSTOMER") {
+		return ra.ScopeType == "CUSTOMER"
+	}
+
+	return ra.ScopeType == "ORG_UNIT" && strings.TrimPrefix(ra.OrgUnitId, "id:") == strings.TrimPrefix(scope, "id:")
+}
+
+// resolveRoleAssignee returns the unique ID of the assignee and, for an email address, whether it
+// belongs to a USER or GROUP. An email address of unknown assignee type is looked up as a user first.
+// Unique IDs are returned as is.
+func resolveRoleAssignee(directoryService *directory.Service, assignee, assigneeType string) (string, string, error) {
+	if !isEmail(assignee) {
+		return assignee, assigneeType, nil
+	}
+
+	if assigneeType == "SERVICE_ACCOUNT" {
+		return "", "", fmt.Errorf("service account %s must be assigned by its unique ID", assignee)
+	}
+
+	if assigneeType == "" || assigneeType == "USER" {
+		usersService, diags := GetUsersService(directoryService)
+		if diags.HasError() {
+			return "", "", fmt.Errorf("%s", diags[0].Summary)
+		}
+
+		user, err := usersService.Get(assignee).Fields("id").Do()
+		if err == nil {
+			return user.Id, "USER", nil
+		}
+		if !isNotFound(err) || assigneeType == "USER" {
+			return "", "", fmt.Errorf("error resolving user %s: %w", assignee, err)
+		}
+	}
+
+	groupsService, diags := GetGroupsService(directoryService)
+	if diags.HasError() {
+		return "", "", fmt.Errorf("%s", diags[0].Summary)
+	}
+
+	group, err := groupsService.Get(assignee).Fields("id").Do()
+	if err != nil {
+		if isNotFound(err) && assigneeType == "" {
+			return "", "", fmt.Errorf("no user or group with the email address %s was found", assignee)
+		}
+		return "", "", fmt.Errorf("error resolving group %s: %w", assignee, err)
+	}
+
+	return group.Id, "GROUP", nil
+}
@@ -1,13 +1,16 @@
 package googleworkspace
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceRoleAssignment_basic(t *testing.T) {
@@ -41,6 +44,54 @@ func TestAccResourceRoleAssignment_basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceRoleAssignment_email(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	data := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"password":   acctest.RandString(10),
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleAssignment_email(data),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_role_assignment.test", "assignee_type", "USER"),
+					resource.TestCheckResourceAttrPair("googleworkspace_role_assignment.test", "assignee_id",
+						"googleworkspace_user.test", "id"),
+				),
+			},
+			{
+				ResourceName:            "googleworkspace_role_assignment.test",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccRoleAssignmentImportStateIdFunc("googleworkspace_role_assignment.test"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"etag"},
+			},
+		},
+	})
+}
+
+func testAccRoleAssignmentImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		return fmt.Sprintf("%s/%s/CUSTOMER", rs.Primary.Attributes["role_id"], rs.Primary.Attributes["assigned_to"]), nil
+	}
+}
+
 func TestAccResourceRoleAssignment_orgUnit_invalid(t *testing.T) {
 	t.Parallel()
 
@@ -124,6 +175,29 @@ resource "googleworkspace_role_assignment" "test" {
 `, data)
 }
 
+func testAccRoleAssignment_email(data map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_user" "test" {
+  primary_email = "%{userEmail}@%{domainName}"
+  password = "%{password}"
+
+  name {
+    family_name = "Scott"
+    given_name = "Michael"
+  }
+}
+
+data "googleworkspace_role" "test" {
+  name = "_GROUPS_ADMIN_ROLE"
+}
+
+resource "googleworkspace_role_assignment" "test" {
+  role_id = data.googleworkspace_role.test.id
+  assigned_to = googleworkspace_user.test.primary_email
+}
+`, data)
+}
+
 func testAccRoleAssignment_orgUnit_invalid(data map[string]interface{}) string {
 	return Nprintf(`
 data "googleworkspace_privileges" "privileges" {}
@@ -211,3 +285,101 @@ resource "googleworkspace_role_assignment" "test" {
 }
 `, data)
 }
+
+func newFakeRoleAssignmentsClient(t *testing.T) *apiClient {
+	client, _ := newFakeApiServer(t, "/admin/directory/v1", func(w http.ResponseWriter, r *http.Request, path string) {
+		switch {
+		case path == "/users/michael@example.com":
+			fmt.Fprint(w, `{"id": "111"}`)
+		case path == "/groups/managers@example.com":
+			fmt.Fprint(w, `{"id": "222"}`)
+		case path == "/customer/my_customer/roleassignments" && r.URL.Query().Get("roleId") == "42":
+			fmt.Fprint(w, `{"items": [
+				{"roleAssignmentId": "1", "roleId": "42", "assignedTo": "111", "scopeType": "CUSTOMER"},
+				{"roleAssignmentId": "2", "roleId": "42", "assignedTo": "111", "scopeType": "ORG_UNIT", "orgUnitId": "id:ou1"},
+				{"roleAssignmentId": "3", "roleId": "42", "assignedTo": "222", "scopeType": "CUSTOMER"},
+				{"roleAssignmentId": "4", "roleId": "42", "assignedTo": "222", "scopeType": "CUSTOMER", "condition": "c"}
+			]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "Resource Not Found"}}`)
+		}
+	})
+	client.Customer = "my_customer"
+
+	return client
+}
+
+func TestResolveRoleAssignee(t *testing.T) {
+	client := newFakeRoleAssignmentsClient(t)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	cases := []struct {
+		assignee, assigneeType string
+		wantId, wantType       string
+		wantErr                bool
+	}{
+		{assignee: "123", assigneeType: "SERVICE_ACCOUNT", wantId: "123", wantType: "SERVICE_ACCOUNT"},
+		{assignee: "michael@example.com", wantId: "111", wantType: "USER"},
+		{assignee: "managers@example.com", wantId: "222", wantType: "GROUP"},
+		{assignee: "managers@example.com", assigneeType: "GROUP", wantId: "222", wantType: "GROUP"},
+		{assignee: "managers@example.com", assigneeType: "USER", wantErr: true},
+		{assignee: "nobody@example.com", wantErr: true},
+		{assignee: "robot@project.iam.gserviceaccount.com", assigneeType: "SERVICE_ACCOUNT", wantErr: true},
+	}
+
+	for _, c := range cases {
+		id, assigneeType, err := resolveRoleAssignee(directoryService, c.assignee, c.assigneeType)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("%s (%s): expected an error", c.assignee, c.assigneeType)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s (%s): unexpected error: %v", c.assignee, c.assigneeType, err)
+			continue
+		}
+		if id != c.wantId || assigneeType != c.wantType {
+			t.Errorf("%s (%s): expected %s/%s, got %s/%s", c.assignee, c.assigneeType, c.wantId, c.wantType, id, assigneeType)
+		}
+	}
+}
+
+func TestResourceRoleAssignmentImport(t *testing.T) {
+	client := newFakeRoleAssignmentsClient(t)
+
+	cases := map[string]string{
+		"7":                               "7",
+		"42/michael@example.com/CUSTOMER": "1",
+		"42/111/customer":                 "1",
+		"42/michael@example.com/ou1":      "2",
+		"42/michael@example.com/id:ou1":   "2",
+	}
+
+	for importId, wantId := range cases {
+		d := resourceRoleAssignment().TestResourceData()
+		d.SetId(importId)
+
+		if _, err := resourceRoleAssignmentImport(context.Background(), d, client); err != nil {
+			t.Errorf("%s: unexpected error: %v", importId, err)
+			continue
+		}
+		if d.Id() != wantId {
+			t.Errorf("%s: expected id %s, got %s", importId, wantId, d.Id())
+		}
+	}
+
+	for _, importId := range []string{"42/managers@example.com/CUSTOMER", "42/michael@example.com/ou2", "42/michael@example.com", "42//CUSTOMER"} {
+		d := resourceRoleAssignment().TestResourceData()
+		d.SetId(importId)
+
+		if _, err := resourceRoleAssignmentImport(context.Background(), d, client); err == nil {
+			t.Errorf("%s: expected an error", importId)
+		}
+	}
+}
@@ -0,0 +1,500 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+// roleAssignee is an assignee of a role, as configured in googleworkspace_role_assignments.
+type roleAssignee struct {
+	AssignedTo   string
+	AssigneeType string
+	Condition    string
+
+	// AssigneeId is the unique ID assigned_to resolves to
+	AssigneeId string
+}
+
+// key identifies the assignment of the role to the assignee, as the same assignee can be
+// assigned a role more than once with different conditions.
+func (a roleAssignee) key() string {
+	return a.AssigneeId + "\x00" + a.Condition
+}
+
+func resourceRoleAssignments() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Role Assignments resource authoritatively manages all assignees of a role in a scope. " +
+			"Assignments made outside of Terraform, e.g. in the Admin console, are detected and removed on the " +
+			"next apply, except for `protected_assignees`. Do not use it together with " +
+			"`googleworkspace_role_assignment` for the same role and scope. Role Assignments resides under the " +
+			"`https://www.googleapis.com/auth/admin.directory.rolemanagement` client scope, resolving email " +
+			"addresses additionally requires the `https://www.googleapis.com/auth/admin.directory.user.readonly` " +
+			"and `https://www.googleapis.com/auth/admin.directory.group.readonly` client scopes.",
+
+		CreateContext: resourceRoleAssignmentsCreate,
+		ReadContext:   resourceRoleAssignmentsRead,
+		UpdateContext: resourceRoleAssignmentsUpdate,
+		DeleteContext: resourceRoleAssignmentsDelete,
+
+		CustomizeDiff: resourceRoleAssignmentsCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRoleAssignmentsImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"role_id": {
+				Description: "The ID of the role that is assigned.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"scope_type": {
+				Description: "The scope in which the role is assigned. Only the assignments in this scope are " +
+					"managed. Valid values are :" +
+					"\n\t- `CUSTOMER`" +
+					"\n\t- `ORG_UNIT`",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "CUSTOMER",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"CUSTOMER", "ORG_UNIT"}, true)),
+				ForceNew:         true,
+			},
+			"org_unit_id": {
+				Description:      "If `scope_type` is `ORG_UNIT`, the ID of the organization unit the role is restricted to.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: diffSuppressOrgUnitId,
+			},
+			"assignees": {
+				Description: "The complete set of assignees of the role in the scope.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"assigned_to": {
+							Description: "The unique ID or email address of the user or group, or the unique ID of " +
+								"the service account, the role is assigned to.",
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"assignee_type": {
+							Description: "The type of the assignee, used to resolve an email address in " +
+								"`assigned_to`. If unset, the email address is looked up as a user, then as a group. " +
+								"Valid values are `USER`, `GROUP` and `SERVICE_ACCOUNT`.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"USER", "GROUP", "SERVICE_ACCOUNT"}, false)),
+						},
+						"condition": {
+							Description: "The condition associated with the role assignment. Changing it replaces " +
+								"the role assignment.",
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"protected_assignees": {
+				Description: "The unique IDs or email addresses of assignees, e.g. break-glass super admins, whose " +
+					"assignments of the role are never removed. Their assignments are not reported in `assignees` " +
+					"unless configured there.",
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"role_assignment_ids": {
+				Description: "The IDs of the managed role assignments, sorted.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceRoleAssignmentsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	roleId := d.Get("role_id").(string)
+	log.Printf("[DEBUG] Creating Role Assignments for Role %q", roleId)
+
+	scope, diags := roleAssignmentsScope(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags = applyRoleAssignments(ctx, d, meta, scope)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", roleId, scope))
+
+	log.Printf("[DEBUG] Finished creating Role Assignments %q", d.Id())
+
+	return resourceRoleAssignmentsRead(ctx, d, meta)
+}
+
+func resourceRoleAssignmentsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	log.Printf("[DEBUG] Getting Role Assignments %q", d.Id())
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	scope, diags := roleAssignmentsScope(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	current, err := listRoleAssignmentsInScope(ctx, directoryService, client.Customer, d.Get("role_id").(string), scope)
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	desired, err := expandRoleAssignees(directoryService, d.Get("assignees").(*schema.Set))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	protected, err := resolveProtectedRoleAssignees(directoryService, d.Get("protected_assignees").(*schema.Set))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	assignees, ids := flattenRoleAssignees(current, desired, protected)
+	if err := d.Set("assignees", assignees); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role_assignment_ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finished getting Role Assignments %q", d.Id())
+
+	return diags
+}
+
+func resourceRoleAssignmentsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Updating Role Assignments %q", d.Id())
+
+	scope, diags := roleAssignmentsScope(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags = applyRoleAssignments(ctx, d, meta, scope)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished updating Role Assignments %q", d.Id())
+
+	return resourceRoleAssignmentsRead(ctx, d, meta)
+}
+
+func resourceRoleAssignmentsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Deleting Role Assignments %q", d.Id())
+
+	scope, diags := roleAssignmentsScope(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	// removing every assignee removes all but the protected assignments
+	d.Set("assignees", []interface{}{})
+
+	diags = applyRoleAssignments(ctx, d, meta, scope)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished deleting Role Assignments %q", d.Id())
+
+	return diags
+}
+
+// the IDs of the assignments are only known after they're added or removed
+func resourceRoleAssignmentsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.HasChange("assignees") || d.HasChange("protected_assignees") {
+		return d.SetNewComputed("role_assignment_ids")
+	}
+
+	return nil
+}
+
+func resourceRoleAssignmentsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+
+	// id is of format "<role_id>/<CUSTOMER or org_unit_id>"
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("role assignments id (%s) is not of the correct format (<role_id>/<CUSTOMER or org_unit_id>)", d.Id())
+	}
+
+	d.Set("role_id", parts[0])
+	if strings.EqualFold(parts[1], "CUSTOMER") {
+		d.Set("scope_type", "CUSTOMER")
+	} else {
+		d.Set("scope_type", "ORG_UNIT")
+		d.Set("org_unit_id", strings.TrimPrefix(parts[1], "id:"))
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// roleAssignmentsScope returns the scope of the assignments, either CUSTOMER or the org unit ID.
+func roleAssignmentsScope(d *schema.ResourceData) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if strings.ToUpper(d.Get("scope_type").(string)) == "CUSTOMER" {
+		return "CUSTOMER", diags
+	}
+
+	orgUnitId := strings.TrimPrefix(d.Get("org_unit_id").(string), "id:")
+	if orgUnitId == "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Attribute cannot be empty",
+			Detail:        "if 'scope_type' is set to ORG_UNIT then 'org_unit_id' must be set",
+			AttributePath: cty.IndexStringPath("org_unit_id"),
+		})
+	}
+
+	return orgUnitId, diags
+}
+
+// applyRoleAssignments inserts the missing assignments of the role and deletes the assignments that
+// are neither configured nor protected. New assignments are inserted first, so that changing the
+// condition of an assignment doesn't leave the assignee without the role.
+func applyRoleAssignments(ctx context.Context, d *schema.ResourceData, meta interface{}, scope string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	roleId := d.Get("role_id").(string)
+	roleIdInt64, err := strconv.ParseInt(roleId, 10, 64)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	roleAssignmentsService, diags := GetRoleAssignmentsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	current, err := listRoleAssignmentsInScope(ctx, directoryService, client.Customer, roleId, scope)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	desired, err := expandRoleAssignees(directoryService, d.Get("assignees").(*schema.Set))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	protected, err := resolveProtectedRoleAssignees(directoryService, d.Get("protected_assignees").(*schema.Set))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	add, remove := diffRoleAssignments(current, desired, protected)
+
+	for _, assignee := range add {
+		log.Printf("[DEBUG] Assigning Role %q to %q", roleId, assignee.AssignedTo)
+
+		ra := &directory.RoleAssignment{
+			AssignedTo: assignee.AssigneeId,
+			RoleId:     roleIdInt64,
+			ScopeType:  "CUSTOMER",
+			Condition:  assignee.Condition,
+		}
+		if scope != "CUSTOMER" {
+			ra.ScopeType = "ORG_UNIT"
+			ra.OrgUnitId = scope
+		}
+
+		_, err := roleAssignmentsService.Insert(client.Customer, ra).Do()
+		if err != nil {
+			return diag.Errorf("error assigning role %s to %s: %s", roleId, assignee.AssignedTo, err)
+		}
+	}
+
+	for _, ra := range remove {
+		log.Printf("[DEBUG] Removing RoleAssignment %d of Role %q from %q", ra.RoleAssignmentId, roleId, ra.AssignedTo)
+
+		err := roleAssignmentsService.Delete(client.Customer, strconv.FormatInt(ra.RoleAssignmentId, 10)).Do()
+		if err != nil && !isNotFound(err) {
+			return diag.Errorf("error removing role %s from %s: %s", roleId, ra.AssignedTo, err)
+		}
+	}
+
+	return diags
+}
+
+// listRoleAssignmentsInScope returns the assignments of the role in the scope, either CUSTOMER or an org unit ID.
+func listRoleAssignmentsInScope(ctx context.Context, directoryService *directory.Service, customer, roleId, scope string) ([]*directory.RoleAssignment, error) {
+	roleAssignmentsService, diags := GetRoleAssignmentsService(directoryService)
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s", diags[0].Summary)
+	}
+
+	var result []*directory.RoleAssignment
+	err := roleAssignmentsService.List(customer).RoleId(roleId).Pages(ctx, func(resp *directory.RoleAssignments) error {
+		for _, ra := range resp.Items {
+			if roleAssignmentInScope(ra, scope) {
+				result = append(result, ra)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// expandRoleAssignees returns the configured assignees, with assigned_to resolved to the unique ID.
+func expandRoleAssignees(directoryService *directory.Service, set *schema.Set) ([]roleAssignee, error) {
+	var result []roleAssignee
+	for _, v := range set.List() {
+		m := v.(map[string]interface{})
+
+		assignee := roleAssignee{
+			AssignedTo:   m["assigned_to"].(string),
+			AssigneeType: m["assignee_type"].(string),
+			Condition:    m["condition"].(string),
+		}
+
+		id, _, err := resolveRoleAssignee(directoryService, assignee.AssignedTo, assignee.AssigneeType)
+		if err != nil {
+			return nil, err
+		}
+		assignee.AssigneeId = id
+
+		result = append(result, assignee)
+	}
+
+	return result, nil
+}
+
+// resolveProtectedRoleAssignees returns the unique IDs of the protected assignees.
+func resolveProtectedRoleAssignees(directoryService *directory.Service, set *schema.Set) (map[string]bool, error) {
+	result := map[string]bool{}
+	for _, assignee := range listOfInterfacestoStrings(set.List()) {
+		id, _, err := resolveRoleAssignee(directoryService, assignee, "")
+		if err != nil {
+			return nil, err
+		}
+		result[id] = true
+	}
+
+	return result, nil
+}
+
+// diffRoleAssignments returns the desired assignees that are not assigned the role and the current
+// assignments that are neither desired nor protected.
+func diffRoleAssignments(current []*directory.RoleAssignment, desired []roleAssignee, protected map[string]bool) ([]roleAssignee, []*directory.RoleAssignment) {
+	currentKeys := map[string]bool{}
+	for _, ra := range current {
+		currentKeys[roleAssignee{AssigneeId: ra.AssignedTo, Condition: ra.Condition}.key()] = true
+	}
+
+	desiredKeys := map[string]bool{}
+	var add []roleAssignee
+	for _, assignee := range desired {
+		if desiredKeys[assignee.key()] {
+			continue
+		}
+		desiredKeys[assignee.key()] = true
+
+		if !currentKeys[assignee.key()] {
+			add = append(add, assignee)
+		}
+	}
+
+	var remove []*directory.RoleAssignment
+	for _, ra := range current {
+		if protected[ra.AssignedTo] {
+			log.Printf("[DEBUG] Keeping RoleAssignment %d of protected assignee %q", ra.RoleAssignmentId, ra.AssignedTo)
+			continue
+		}
+		if !desiredKeys[roleAssignee{AssigneeId: ra.AssignedTo, Condition: ra.Condition}.key()] {
+			remove = append(remove, ra)
+		}
+	}
+
+	return add, remove
+}
+
+// flattenRoleAssignees returns the current assignees in the form they're configured in, so that only
+// assignments made outside of Terraform show up as a difference, and the IDs of the managed assignments.
+// The assignments of protected assignees are omitted unless configured.
+func flattenRoleAssignees(current []*directory.RoleAssignment, desired []roleAssignee, protected map[string]bool) ([]map[string]interface{}, []string) {
+	configured := map[string]roleAssignee{}
+	for _, assignee := range desired {
+		configured[assignee.key()] = assignee
+	}
+
+	assignees := []map[string]interface{}{}
+	ids := []string{}
+	for _, ra := range current {
+		assignee, ok := configured[roleAssignee{AssigneeId: ra.AssignedTo, Condition: ra.Condition}.key()]
+		if !ok {
+			if protected[ra.AssignedTo] {
+				continue
+			}
+
+			assignee = roleAssignee{
+				AssignedTo: ra.AssignedTo,
+				Condition:  ra.Condition,
+			}
+		}
+
+		assignees = append(assignees, map[string]interface{}{
+			"assigned_to":   assignee.AssignedTo,
+			"assignee_type": assignee.AssigneeType,
+			"condition":     assignee.Condition,
+		})
+		ids = append(ids, strconv.FormatInt(ra.RoleAssignmentId, 10))
+	}
+
+	sort.Strings(ids)
+
+	return assignees, ids
+}
@@ -0,0 +1,233 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func TestDiffRoleAssignments(t *testing.T) {
+	current := []*directory.RoleAssignment{
+		{RoleAssignmentId: 1, AssignedTo: "111"},
+		{RoleAssignmentId: 2, AssignedTo: "222", Condition: "old"},
+		{RoleAssignmentId: 3, AssignedTo: "333"},
+		{RoleAssignmentId: 4, AssignedTo: "999"},
+	}
+	desired := []roleAssignee{
+		{AssignedTo: "michael@example.com", AssigneeId: "111"},
+		{AssignedTo: "222", AssigneeId: "222", Condition: "new"},
+		{AssignedTo: "444", AssigneeId: "444"},
+		{AssignedTo: "444", AssigneeId: "444", AssigneeType: "USER"},
+	}
+
+	add, remove := diffRoleAssignments(current, desired, map[string]bool{"999": true})
+
+	var added []string
+	for _, a := range add {
+		added = append(added, a.AssigneeId+"/"+a.Condition)
+	}
+	if want := []string{"222/new", "444/"}; !reflect.DeepEqual(added, want) {
+		t.Errorf("expected to add %v, got %v", want, added)
+	}
+
+	var removed []int64
+	for _, ra := range remove {
+		removed = append(removed, ra.RoleAssignmentId)
+	}
+	if want := []int64{2, 3}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("expected to remove %v, got %v", want, removed)
+	}
+}
+
+func TestFlattenRoleAssignees(t *testing.T) {
+	current := []*directory.RoleAssignment{
+		{RoleAssignmentId: 12, AssignedTo: "111"},
+		{RoleAssignmentId: 3, AssignedTo: "333", Condition: "c"},
+		{RoleAssignmentId: 4, AssignedTo: "999"},
+		{RoleAssignmentId: 5, AssignedTo: "888"},
+	}
+	desired := []roleAssignee{
+		{AssignedTo: "michael@example.com", AssigneeType: "USER", AssigneeId: "111"},
+		{AssignedTo: "888", AssigneeId: "888"},
+	}
+
+	assignees, ids := flattenRoleAssignees(current, desired, map[string]bool{"999": true, "888": true})
+
+	wantAssignees := []map[string]interface{}{
+		{"assigned_to": "michael@example.com", "assignee_type": "USER", "condition": ""},
+		{"assigned_to": "333", "assignee_type": "", "condition": "c"},
+		{"assigned_to": "888", "assignee_type": "", "condition": ""},
+	}
+	if !reflect.DeepEqual(assignees, wantAssignees) {
+		t.Errorf("expected assignees %v, got %v", wantAssignees, assignees)
+	}
+	if want := []string{"12", "3", "5"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("expected ids %v, got %v", want, ids)
+	}
+}
+
+func TestResourceRoleAssignmentsImport(t *testing.T) {
+	d := resourceRoleAssignments().TestResourceData()
+	d.SetId("42/id:ou1")
+
+	if _, err := resourceRoleAssignmentsImport(context.Background(), d, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Get("role_id") != "42" || d.Get("scope_type") != "ORG_UNIT" || d.Get("org_unit_id") != "ou1" {
+		t.Errorf("unexpected role_id %v, scope_type %v, org_unit_id %v", d.Get("role_id"), d.Get("scope_type"), d.Get("org_unit_id"))
+	}
+
+	for _, id := range []string{"42", "42/", "/CUSTOMER", "42/CUSTOMER/x"} {
+		d := resourceRoleAssignments().TestResourceData()
+		d.SetId(id)
+
+		if _, err := resourceRoleAssignmentsImport(context.Background(), d, nil); err == nil {
+			t.Errorf("%s: expected an error", id)
+		}
+	}
+}
+
+func TestApplyRoleAssignments(t *testing.T) {
+	client, requests := newFakeApiServer(t, "/admin/directory/v1", func(w http.ResponseWriter, r *http.Request, path string) {
+		switch {
+		case r.Method == http.MethodGet && path == "/users/breakglass@example.com":
+			fmt.Fprint(w, `{"id": "999"}`)
+		case r.Method == http.MethodGet && path == "/customer/my_customer/roleassignments":
+			fmt.Fprint(w, `{"items": [
+				{"roleAssignmentId": "1", "roleId": "42", "assignedTo": "111", "scopeType": "CUSTOMER"},
+				{"roleAssignmentId": "2", "roleId": "42", "assignedTo": "222", "scopeType": "CUSTOMER"},
+				{"roleAssignmentId": "3", "roleId": "42", "assignedTo": "999", "scopeType": "CUSTOMER"},
+				{"roleAssignmentId": "4", "roleId": "42", "assignedTo": "222", "scopeType": "ORG_UNIT", "orgUnitId": "id:ou1"}
+			]}`)
+		case r.Method == http.MethodPost && path == "/customer/my_customer/roleassignments":
+			fmt.Fprint(w, `{"roleAssignmentId": "5"}`)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "Resource Not Found"}}`)
+		}
+	})
+	client.Customer = "my_customer"
+
+	d := schema.TestResourceDataRaw(t, resourceRoleAssignments().Schema, map[string]interface{}{
+		"role_id": "42",
+		"assignees": []interface{}{
+			map[string]interface{}{"assigned_to": "111"},
+			map[string]interface{}{"assigned_to": "333", "assignee_type": "SERVICE_ACCOUNT"},
+		},
+		"protected_assignees": []interface{}{"breakglass@example.com"},
+	})
+
+	if diags := applyRoleAssignments(context.Background(), d, client, "CUSTOMER"); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var changes []string
+	for _, r := range requests() {
+		if !strings.HasPrefix(r, "GET") {
+			changes = append(changes, r)
+		}
+	}
+	sort.Strings(changes)
+
+	want := []string{
+		"DELETE /customer/my_customer/roleassignments/2",
+		`POST /customer/my_customer/roleassignments {"assignedTo":"333","roleId":"42","scopeType":"CUSTOMER"}`,
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("expected changes %v, got %v", want, changes)
+	}
+}
+
+func TestAccResourceRoleAssignments_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	data := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"roleName":   fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"password":   acctest.RandString(10),
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleAssignments_basic(data),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_role_assignments.test", "assignees.#", "1"),
+					resource.TestCheckResourceAttr("googleworkspace_role_assignments.test", "role_assignment_ids.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_role_assignments.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the imported assignee is the user ID rather than the email address
+				ImportStateVerifyIgnore: []string{"assignees"},
+			},
+		},
+	})
+}
+
+func testAccRoleAssignments_basic(data map[string]interface{}) string {
+	return Nprintf(`
+data "googleworkspace_privileges" "privileges" {}
+
+locals {
+  read_only_privileges = [
+    for priv in data.googleworkspace_privileges.privileges.items : priv
+    if length(regexall("READ", priv.privilege_name)) > 0
+  ]
+}
+
+# a custom role, so that no existing assignments are removed
+resource "googleworkspace_role" "test" {
+  name = "%{roleName}"
+
+  dynamic "privileges" {
+    for_each = local.read_only_privileges
+    content {
+      service_id     = privileges.value["service_id"]
+      privilege_name = privileges.value["privilege_name"]
+    }
+  }
+}
+
+resource "googleworkspace_user" "test" {
+  primary_email = "%{userEmail}@%{domainName}"
+  password      = "%{password}"
+
+  name {
+    family_name = "Scott"
+    given_name  = "Michael"
+  }
+}
+
+resource "googleworkspace_role_assignments" "test" {
+  role_id = googleworkspace_role.test.id
+
+  assignees {
+    assigned_to = googleworkspace_user.test.primary_email
+  }
+}
+`, data)
+}
@@ -2,10 +2,13 @@ package googleworkspace
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	directory "google.golang.org/api/admin/directory/v1"
 )
 
 func TestAccResourceRole_basic(t *testing.T) {
@@ -133,3 +136,124 @@ resource "googleworkspace_role" "test" {
 }
 `, name, description)
 }
+
+func testPrivilegeIndex() *privilegeIndex {
+	return newPrivilegeIndex([]*directory.Privilege{
+		{
+			PrivilegeName: "A",
+			ServiceId:     "1",
+			ChildPrivileges: []*directory.Privilege{
+				{
+					PrivilegeName: "AA",
+					ServiceId:     "1",
+					ChildPrivileges: []*directory.Privilege{
+						{
+							PrivilegeName: "AAA",
+							ServiceId:     "1",
+						},
+					},
+				},
+				{
+					PrivilegeName: "AB",
+					ServiceId:     "1",
+				},
+			},
+		},
+		{
+			PrivilegeName: "B",
+			ServiceId:     "2",
+		},
+		{
+			PrivilegeName: "B",
+			ServiceId:     "3",
+		},
+	})
+}
+
+func privilegeKeys(privileges []*directory.RoleRolePrivileges) []string {
+	var keys []string
+	for _, priv := range privileges {
+		keys = append(keys, privilegeKey(priv.PrivilegeName, priv.ServiceId))
+	}
+	return keys
+}
+
+func TestPrivilegeIndex(t *testing.T) {
+	t.Parallel()
+
+	index := testPrivilegeIndex()
+
+	for _, key := range []string{"A:1", "AA:1", "AAA:1", "AB:1", "B:2", "B:3"} {
+		if !index.known[key] {
+			t.Errorf("expected %s to be known", key)
+		}
+	}
+	if !reflect.DeepEqual(index.serviceIds["B"], []string{"2", "3"}) {
+		t.Errorf("unexpected service IDs for B: %v", index.serviceIds["B"])
+	}
+
+	if !index.isDescendant("AAA:1", "A:1") || !index.isDescendant("AAA:1", "AA:1") || !index.isDescendant("AB:1", "A:1") {
+		t.Errorf("expected AA, AAA and AB to descend from A")
+	}
+	if index.isDescendant("AB:1", "AA:1") || index.isDescendant("A:1", "AA:1") || index.isDescendant("B:2", "A:1") {
+		t.Errorf("unexpected descendant")
+	}
+}
+
+func TestValidateRolePrivileges(t *testing.T) {
+	t.Parallel()
+
+	index := testPrivilegeIndex()
+
+	if err := validateRolePrivileges([]*directory.RoleRolePrivileges{
+		{PrivilegeName: "AA", ServiceId: "1"},
+		{PrivilegeName: "B", ServiceId: "3"},
+	}, index); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := validateRolePrivileges([]*directory.RoleRolePrivileges{
+		{PrivilegeName: "A", ServiceId: "2"},
+		{PrivilegeName: "C", ServiceId: "1"},
+	}, index)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"A (service_id 2), it exists for service_id 1", "C (service_id 1)"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error %q", want, err)
+		}
+	}
+}
+
+func TestExpandChildPrivileges(t *testing.T) {
+	t.Parallel()
+
+	actual := expandChildPrivileges([]*directory.RoleRolePrivileges{
+		{PrivilegeName: "AA", ServiceId: "1"},
+		{PrivilegeName: "A", ServiceId: "1"},
+		{PrivilegeName: "B", ServiceId: "2"},
+	}, testPrivilegeIndex())
+
+	expected := []string{"AA:1", "A:1", "B:2", "AAA:1", "AB:1"}
+	if keys := privilegeKeys(actual); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("expected %v, got %v", expected, keys)
+	}
+}
+
+func TestPruneImpliedPrivileges(t *testing.T) {
+	t.Parallel()
+
+	actual := pruneImpliedPrivileges([]*directory.RoleRolePrivileges{
+		{PrivilegeName: "A", ServiceId: "1"},
+		{PrivilegeName: "AA", ServiceId: "1"},
+		{PrivilegeName: "AAA", ServiceId: "1"},
+		{PrivilegeName: "AB", ServiceId: "1"},
+		{PrivilegeName: "B", ServiceId: "2"},
+	}, map[string]bool{"A:1": true, "AB:1": true}, testPrivilegeIndex())
+
+	expected := []string{"A:1", "AB:1", "B:2"}
+	if keys := privilegeKeys(actual); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("expected %v, got %v", expected, keys)
+	}
+}
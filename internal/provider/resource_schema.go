@@ -2,8 +2,12 @@ package googleworkspace
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -16,14 +20,24 @@ import (
 func resourceSchema() *schema.Resource {
 	return &schema.Resource{
 		// This description is used by the documentation generator and the language server.
-		Description: "Schema resource manages Google Workspace Schemas. Schema resides " +
-			"under the `https://www.googleapis.com/auth/admin.directory.userschema` client scope.",
+		Description: "Schema resource manages Google Workspace Schemas. Changes to the fields are classified when " +
+			"planning: adding a field or changing its display name, indexing or numeric indexing spec is safe, " +
+			"changing its read access type is risky, and removing a field, changing its type or whether it's " +
+			"multi-valued, or replacing the schema is destructive, as the values of the users for the field are lost. " +
+			"Destructive changes fail to plan unless `allow_destructive_changes` is set, and the error lists the risky " +
+			"changes along with them. Risky changes on their own don't fail to plan, and are only logged as warnings, " +
+			"shown with `TF_LOG=WARN`. A field type or multi-valued flag that is only known after apply is treated " +
+			"as a destructive change, as is a list of fields that is only known after apply. Schema resides " +
+			"under the `https://www.googleapis.com/auth/admin.directory.userschema` client scope, and counting the " +
+			"affected users requires the `https://www.googleapis.com/auth/admin.directory.user.readonly` client scope.",
 
 		CreateContext: resourceSchemaCreate,
 		ReadContext:   resourceSchemaRead,
 		UpdateContext: resourceSchemaUpdate,
 		DeleteContext: resourceSchemaDelete,
 
+		CustomizeDiff: resourceSchemaCustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(1 * time.Minute),
 			Update: schema.DefaultTimeout(1 * time.Minute),
@@ -31,7 +45,7 @@ func resourceSchema() *schema.Resource {
 		},
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceSchemaImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,10 +67,10 @@ func resourceSchema() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"field_name": {
-							Description: "The name of the field.",
-							Type:        schema.TypeString,
-							Required:    true,
-							ForceNew:    true,
+							Description: "The name of the field. Fields are matched by name when planning, " +
+								"so renaming a field removes it and adds a new one.",
+							Type:     schema.TypeString,
+							Required: true,
 						},
 						"field_id": {
 							Description: "The unique identifier of the field.",
@@ -71,10 +85,10 @@ func resourceSchema() *schema.Resource {
 								"\n\t- `EMAIL`" +
 								"\n\t- `INT64`" +
 								"\n\t- `PHONE`" +
-								"\n\t- `STRING`",
+								"\n\t- `STRING`" +
+								"\n\tChanging the type of a field replaces the schema.",
 							Type:     schema.TypeString,
 							Required: true,
-							ForceNew: true,
 							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
 								"BOOL", "DATE", "DOUBLE", "EMAIL", "INT64", "PHONE", "STRING"}, true)),
 						},
@@ -150,6 +164,20 @@ func resourceSchema() *schema.Resource {
 				Optional:    true,
 				Computed:    true,
 			},
+			"allow_destructive_changes": {
+				Description: "If `true`, changes that lose the values of the users for a field are planned. " +
+					"Otherwise they fail to plan.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"count_affected_users": {
+				Description: "If `true`, the number of users holding values for the fields affected by " +
+					"destructive changes is counted when planning, and shown in the error.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"etag": {
 				Description: "ETag of the resource.",
 				Type:        schema.TypeString,
@@ -247,6 +275,7 @@ func resourceSchemaRead(ctx context.Context, d *schema.ResourceData, meta interf
 	d.Set("fields", flattenFields(schema.Fields))
 	d.Set("display_name", schema.DisplayName)
 	d.Set("etag", schema.Etag)
+
 	d.SetId(schema.SchemaId)
 	log.Printf("[DEBUG] Finished getting Schema %q: %#v", d.Id(), schemaName)
 
@@ -272,24 +301,14 @@ func resourceSchemaUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 		return diags
 	}
 
-	schemaObj := directory.Schema{}
-
-	// Strings
-
-	if d.HasChange("schema_name") {
-		schemaObj.SchemaName = schemaName
-	}
-
-	if d.HasChange("fields") {
-		schemaObj.Fields = expandFields(d.Get("fields"))
-	}
-
-	if d.HasChange("display_name") {
-		schemaObj.DisplayName = d.Get("display_name").(string)
-	}
-
-	if &schemaObj != new(directory.Schema) {
-		schemaObj.SchemaId = d.Id()
+	// allow_destructive_changes and count_affected_users are only used when planning
+	if d.HasChanges("fields", "display_name") {
+		schemaObj := directory.Schema{
+			SchemaId:    d.Id(),
+			SchemaName:  schemaName,
+			Fields:      expandFields(d.Get("fields")),
+			DisplayName: d.Get("display_name").(string),
+		}
 
 		err := retryTimeDuration(ctx, d.Timeout(schema.TimeoutUpdate), func() error {
 			definedSchema, retryErr := schemasService.Update(client.Customer, d.Id(), &schemaObj).Do()
@@ -310,6 +329,14 @@ func resourceSchemaUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 	return resourceSchemaRead(ctx, d, meta)
 }
 
+func resourceSchemaImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// allow_destructive_changes and count_affected_users are not returned in the response, so default them on import
+	d.Set("allow_destructive_changes", false)
+	d.Set("count_affected_users", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceSchemaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -346,6 +373,263 @@ func resourceSchemaDelete(ctx context.Context, d *schema.ResourceData, meta inte
 	return diags
 }
 
+// schemaFieldChanges are the changes to the fields of a schema, classified by their impact on user data.
+type schemaFieldChanges struct {
+	safe        []string
+	risky       []string
+	destructive []string
+	// affectedFields are the names of the existing fields whose values are lost
+	affectedFields []string
+	// replace is whether a field changes type, which the API can't update
+	replace bool
+}
+
+// classifySchemaFieldChanges matches the old and new fields by name, as their position in the list
+// doesn't matter to the API.
+func classifySchemaFieldChanges(oldFields, newFields []interface{}) schemaFieldChanges {
+	var changes schemaFieldChanges
+
+	newByName := map[string]map[string]interface{}{}
+	for _, f := range newFields {
+		field := f.(map[string]interface{})
+		newByName[field["field_name"].(string)] = field
+	}
+
+	oldByName := map[string]map[string]interface{}{}
+	for _, f := range oldFields {
+		old := f.(map[string]interface{})
+		name := old["field_name"].(string)
+		oldByName[name] = old
+
+		new, ok := newByName[name]
+		if !ok {
+			changes.destructive = append(changes.destructive, fmt.Sprintf("field %q is removed", name))
+			changes.affectedFields = append(changes.affectedFields, name)
+			continue
+		}
+
+		affected := false
+		if !strings.EqualFold(old["field_type"].(string), new["field_type"].(string)) {
+			changes.destructive = append(changes.destructive, fmt.Sprintf("field %q changes type from %s to %s",
+				name, old["field_type"], new["field_type"]))
+			changes.replace = true
+			affected = true
+		}
+		if old["multi_valued"].(bool) != new["multi_valued"].(bool) {
+			changes.destructive = append(changes.destructive, fmt.Sprintf("field %q changes multi_valued from %t to %t",
+				name, old["multi_valued"], new["multi_valued"]))
+			affected = true
+		}
+		if affected {
+			changes.affectedFields = append(changes.affectedFields, name)
+		}
+
+		if !strings.EqualFold(old["read_access_type"].(string), new["read_access_type"].(string)) {
+			changes.risky = append(changes.risky, fmt.Sprintf("field %q changes read_access_type from %s to %s",
+				name, old["read_access_type"], new["read_access_type"]))
+		}
+		// display_name is computed, so it's only changed if it's configured
+		if new["display_name"].(string) != "" && old["display_name"].(string) != new["display_name"].(string) {
+			changes.safe = append(changes.safe, fmt.Sprintf("field %q changes display_name", name))
+		}
+		if old["indexed"] != new["indexed"] || !reflect.DeepEqual(old["numeric_indexing_spec"], new["numeric_indexing_spec"]) {
+			changes.safe = append(changes.safe, fmt.Sprintf("field %q changes indexing", name))
+		}
+	}
+
+	for _, f := range newFields {
+		name := f.(map[string]interface{})["field_name"].(string)
+		if _, ok := oldByName[name]; !ok {
+			changes.safe = append(changes.safe, fmt.Sprintf("field %q is added", name))
+		}
+	}
+
+	return changes
+}
+
+// resolveUnknownSchemaFields returns the new fields whose names are known, with the attributes that are
+// only known after apply set to their old values, along with the destructive changes these attributes may
+// make. known reports whether the value of an attribute of the new fields, e.g. `fields.0.field_type`, is
+// known.
+func resolveUnknownSchemaFields(oldFields, newFields []interface{}, known func(key string) bool) ([]interface{}, schemaFieldChanges) {
+	var changes schemaFieldChanges
+
+	oldByName := map[string]map[string]interface{}{}
+	for _, f := range oldFields {
+		old := f.(map[string]interface{})
+		oldByName[old["field_name"].(string)] = old
+	}
+
+	var fields []interface{}
+	for i, f := range newFields {
+		// a field whose name isn't known can't be matched, so the old field it replaces is removed
+		if !known(fmt.Sprintf("fields.%d.field_name", i)) {
+			continue
+		}
+
+		field := map[string]interface{}{}
+		for k, v := range f.(map[string]interface{}) {
+			field[k] = v
+		}
+		fields = append(fields, field)
+
+		name := field["field_name"].(string)
+		old, ok := oldByName[name]
+		if !ok {
+			continue
+		}
+
+		var keys []string
+		for k := range old {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		affected := false
+		for _, k := range keys {
+			if known(fmt.Sprintf("fields.%d.%s", i, k)) {
+				continue
+			}
+			field[k] = old[k]
+
+			switch k {
+			case "field_type":
+				// the API can't update the type of a field, so the schema is replaced in case it changes
+				changes.destructive = append(changes.destructive, fmt.Sprintf("field %q has a field_type that is only known after apply", name))
+				changes.replace = true
+				affected = true
+			case "multi_valued":
+				changes.destructive = append(changes.destructive, fmt.Sprintf("field %q has a multi_valued that is only known after apply", name))
+				affected = true
+			}
+		}
+		if affected {
+			changes.affectedFields = append(changes.affectedFields, name)
+		}
+	}
+
+	return fields, changes
+}
+
+func resourceSchemaCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// nothing is lost when creating the schema
+	if d.Id() == "" {
+		return nil
+	}
+
+	oldFields, newFields := d.GetChange("fields")
+
+	var changes schemaFieldChanges
+	if !d.NewValueKnown("fields") {
+		// none of the fields are known, so the schema is replaced in case their types change
+		changes.destructive = []string{"the fields are only known after apply"}
+		changes.replace = true
+	} else {
+		fields, unknown := resolveUnknownSchemaFields(oldFields.([]interface{}), newFields.([]interface{}), func(key string) bool {
+			return d.NewValueKnown(key)
+		})
+
+		changes = classifySchemaFieldChanges(oldFields.([]interface{}), fields)
+		changes.destructive = append(changes.destructive, unknown.destructive...)
+		changes.affectedFields = append(changes.affectedFields, unknown.affectedFields...)
+		changes.replace = changes.replace || unknown.replace
+	}
+
+	oldSchemaName, _ := d.GetChange("schema_name")
+
+	if changes.replace || d.HasChange("schema_name") {
+		// the values of all fields are lost when the schema is replaced
+		changes.destructive = append(changes.destructive, "the schema is replaced")
+		changes.affectedFields = nil
+		for _, f := range oldFields.([]interface{}) {
+			changes.affectedFields = append(changes.affectedFields, f.(map[string]interface{})["field_name"].(string))
+		}
+	}
+
+	if changes.replace {
+		if err := d.ForceNew("fields"); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range changes.safe {
+		log.Printf("[DEBUG] Schema %q: safe change: %s", oldSchemaName, c)
+	}
+	for _, c := range changes.risky {
+		log.Printf("[WARN] Schema %q: risky change: %s", oldSchemaName, c)
+	}
+
+	if len(changes.destructive) == 0 || d.Get("allow_destructive_changes").(bool) {
+		return nil
+	}
+
+	msg := destructiveSchemaChangesMessage(oldSchemaName.(string), changes)
+
+	if d.Get("count_affected_users").(bool) && len(changes.affectedFields) > 0 {
+		counts, err := countSchemaFieldValues(ctx, meta.(*apiClient), oldSchemaName.(string))
+		if err != nil {
+			return err
+		}
+
+		var affected []string
+		for _, name := range changes.affectedFields {
+			affected = append(affected, fmt.Sprintf("%d users hold values for field %q", counts[name], name))
+		}
+		msg += ". " + strings.Join(affected, ", ")
+	}
+
+	return fmt.Errorf("%s", msg)
+}
+
+// destructiveSchemaChangesMessage describes the destructive changes to a schema that fail the plan,
+// along with its risky changes, which are otherwise only logged.
+func destructiveSchemaChangesMessage(schemaName string, changes schemaFieldChanges) string {
+	msg := fmt.Sprintf("destructive changes to schema %q lose the values of the users for its fields, set "+
+		"allow_destructive_changes to apply them: %s", schemaName, strings.Join(changes.destructive, "; "))
+	if len(changes.risky) > 0 {
+		msg += ". The schema also has risky changes: " + strings.Join(changes.risky, "; ")
+	}
+	return msg
+}
+
+// countSchemaFieldValues returns the number of users holding a value for each field of the schema.
+func countSchemaFieldValues(ctx context.Context, client *apiClient, schemaName string) (map[string]int, error) {
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s", diags[0].Summary)
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s", diags[0].Summary)
+	}
+
+	counts := map[string]int{}
+	err := usersService.List().Customer(client.Customer).Projection("custom").CustomFieldMask(schemaName).Pages(ctx, func(resp *directory.Users) error {
+		for _, user := range resp.Users {
+			raw, ok := user.CustomSchemas[schemaName]
+			if !ok {
+				continue
+			}
+
+			var values map[string]interface{}
+			if err := json.Unmarshal(raw, &values); err != nil {
+				return err
+			}
+
+			for name := range values {
+				counts[name]++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
 // Expand functions
 
 func expandFields(v interface{}) []*directory.SchemaFieldSpec {
@@ -2,6 +2,8 @@ package googleworkspace
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -55,12 +57,172 @@ func TestAccResourceSchema_full(t *testing.T) {
 				ResourceName:            "googleworkspace_schema.my-schema",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"etag", "fields.0.etag", "fields.1.etag", "fields.2.etag"},
+				ImportStateVerifyIgnore: []string{"etag", "fields.0.etag", "fields.1.etag", "fields.2.etag", "allow_destructive_changes"},
+			},
+		},
+	})
+}
+
+func TestAccResourceSchema_destructiveChanges(t *testing.T) {
+	t.Parallel()
+
+	schemaName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSchema_full(schemaName),
+			},
+			{
+				Config:      testAccResourceSchema_removedField(schemaName, false),
+				ExpectError: regexp.MustCompile(`set allow_destructive_changes to apply them: field "indexed" is removed`),
+			},
+			{
+				Config: testAccResourceSchema_removedField(schemaName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_schema.my-schema", "fields.#", "3"),
+					resource.TestCheckResourceAttr("googleworkspace_schema.my-schema", "fields.0.field_name", "nickname"),
+				),
 			},
 		},
 	})
 }
 
+func TestClassifySchemaFieldChanges(t *testing.T) {
+	t.Parallel()
+
+	field := func(name, fieldType string, multiValued bool, readAccessType string) map[string]interface{} {
+		return map[string]interface{}{
+			"field_name":            name,
+			"field_type":            fieldType,
+			"multi_valued":          multiValued,
+			"indexed":               true,
+			"display_name":          "",
+			"read_access_type":      readAccessType,
+			"numeric_indexing_spec": []interface{}{},
+		}
+	}
+
+	oldFields := []interface{}{
+		field("kept", "STRING", false, "ALL_DOMAIN_USERS"),
+		field("removed", "STRING", false, "ALL_DOMAIN_USERS"),
+		field("retyped", "STRING", false, "ALL_DOMAIN_USERS"),
+		field("multi", "INT64", false, "ALL_DOMAIN_USERS"),
+		field("private", "DATE", false, "ALL_DOMAIN_USERS"),
+	}
+	renamed := field("kept", "STRING", false, "ALL_DOMAIN_USERS")
+	renamed["display_name"] = "Kept"
+	newFields := []interface{}{
+		field("added", "BOOL", false, "ALL_DOMAIN_USERS"),
+		renamed,
+		field("retyped", "INT64", false, "ALL_DOMAIN_USERS"),
+		field("multi", "int64", true, "ALL_DOMAIN_USERS"),
+		field("private", "DATE", false, "ADMINS_AND_SELF"),
+	}
+
+	changes := classifySchemaFieldChanges(oldFields, newFields)
+
+	if want := []string{`field "kept" changes display_name`, `field "added" is added`}; !reflect.DeepEqual(changes.safe, want) {
+		t.Errorf("expected safe changes %v, got %v", want, changes.safe)
+	}
+	if want := []string{`field "private" changes read_access_type from ALL_DOMAIN_USERS to ADMINS_AND_SELF`}; !reflect.DeepEqual(changes.risky, want) {
+		t.Errorf("expected risky changes %v, got %v", want, changes.risky)
+	}
+	want := []string{
+		`field "removed" is removed`,
+		`field "retyped" changes type from STRING to INT64`,
+		`field "multi" changes multi_valued from false to true`,
+	}
+	if !reflect.DeepEqual(changes.destructive, want) {
+		t.Errorf("expected destructive changes %v, got %v", want, changes.destructive)
+	}
+	if want := []string{"removed", "retyped", "multi"}; !reflect.DeepEqual(changes.affectedFields, want) {
+		t.Errorf("expected affected fields %v, got %v", want, changes.affectedFields)
+	}
+	if !changes.replace {
+		t.Errorf("expected the schema to be replaced")
+	}
+
+	// reordering the fields changes nothing
+	changes = classifySchemaFieldChanges(oldFields, []interface{}{oldFields[4], oldFields[3], oldFields[2], oldFields[1], oldFields[0]})
+	if len(changes.safe)+len(changes.risky)+len(changes.destructive) > 0 || changes.replace {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestResolveUnknownSchemaFields(t *testing.T) {
+	t.Parallel()
+
+	field := func(name, fieldType string) map[string]interface{} {
+		return map[string]interface{}{
+			"field_name":            name,
+			"field_type":            fieldType,
+			"multi_valued":          false,
+			"indexed":               true,
+			"display_name":          "",
+			"read_access_type":      "ALL_DOMAIN_USERS",
+			"numeric_indexing_spec": []interface{}{},
+		}
+	}
+
+	oldFields := []interface{}{field("retyped", "STRING"), field("described", "STRING"), field("renamed", "STRING")}
+	// unknown values are read as zero values
+	newFields := []interface{}{field("retyped", ""), field("described", "STRING"), field("", "STRING")}
+	newFields[1].(map[string]interface{})["read_access_type"] = ""
+
+	unknown := map[string]bool{
+		"fields.0.field_type":       true,
+		"fields.1.read_access_type": true,
+		"fields.2.field_name":       true,
+	}
+	fields, changes := resolveUnknownSchemaFields(oldFields, newFields, func(key string) bool {
+		return !unknown[key]
+	})
+
+	// unknown values are kept as they are, except for the type, which may replace the schema
+	if want := []interface{}{field("retyped", "STRING"), field("described", "STRING")}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("expected fields %v, got %v", want, fields)
+	}
+	if want := []string{`field "retyped" has a field_type that is only known after apply`}; !reflect.DeepEqual(changes.destructive, want) {
+		t.Errorf("expected destructive changes %v, got %v", want, changes.destructive)
+	}
+	if want := []string{"retyped"}; !reflect.DeepEqual(changes.affectedFields, want) {
+		t.Errorf("expected affected fields %v, got %v", want, changes.affectedFields)
+	}
+	if !changes.replace {
+		t.Errorf("expected the schema to be replaced")
+	}
+
+	// the field whose name isn't known can't be matched, so the old field is removed
+	changes = classifySchemaFieldChanges(oldFields, fields)
+	if want := []string{`field "renamed" is removed`}; !reflect.DeepEqual(changes.destructive, want) {
+		t.Errorf("expected destructive changes %v, got %v", want, changes.destructive)
+	}
+}
+
+func TestDestructiveSchemaChangesMessage(t *testing.T) {
+	t.Parallel()
+
+	changes := schemaFieldChanges{
+		safe:        []string{`field "added" is added`},
+		destructive: []string{`field "removed" is removed`},
+	}
+
+	want := `destructive changes to schema "Employment" lose the values of the users for its fields, set ` +
+		`allow_destructive_changes to apply them: field "removed" is removed`
+	if msg := destructiveSchemaChangesMessage("Employment", changes); msg != want {
+		t.Errorf("expected message %q, got %q", want, msg)
+	}
+
+	changes.risky = []string{`field "private" changes read_access_type from ALL_DOMAIN_USERS to ADMINS_AND_SELF`}
+	want += `. The schema also has risky changes: field "private" changes read_access_type from ALL_DOMAIN_USERS to ADMINS_AND_SELF`
+	if msg := destructiveSchemaChangesMessage("Employment", changes); msg != want {
+		t.Errorf("expected message %q, got %q", want, msg)
+	}
+}
+
 func testAccResourceSchema_basic(schemaName string) string {
 	return fmt.Sprintf(`
 resource "googleworkspace_schema" "my-schema" {
@@ -112,6 +274,8 @@ resource "googleworkspace_schema" "my-schema" {
   schema_name = "%s"
   display_name = "schema test full update"
 
+  allow_destructive_changes = true
+
   fields {
     field_name = "birthday"
     field_type = "DATE"
@@ -138,3 +302,37 @@ resource "googleworkspace_schema" "my-schema" {
 }
 `, schemaName)
 }
+
+func testAccResourceSchema_removedField(schemaName string, allowDestructiveChanges bool) string {
+	return fmt.Sprintf(`
+resource "googleworkspace_schema" "my-schema" {
+  schema_name = "%s-updated"
+  display_name = "schema test full"
+
+  allow_destructive_changes = %t
+  count_affected_users = true
+
+  fields {
+    field_name = "nickname"
+    field_type = "STRING"
+  }
+
+  fields {
+    field_name = "birthday"
+    field_type = "DATE"
+    read_access_type = "ADMINS_AND_SELF"
+  }
+
+  fields {
+    field_name = "favorite_numbers"
+    field_type = "INT64"
+    multi_valued = true
+
+    numeric_indexing_spec {
+      min_value = 1.0
+      max_value = 10.5
+    }
+  }
+}
+`, schemaName, allowDestructiveChanges)
+}
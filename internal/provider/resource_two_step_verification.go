@@ -0,0 +1,179 @@
+package googleworkspace
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceTwoStepVerification() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Two Step Verification resource turns off 2-Step Verification (2SV) for a Google Workspace User, " +
+			"e.g. to recover a user that is locked out of their account. 2SV is turned off when the resource is " +
+			"created, and again whenever `triggers` changes. The user is free to enroll again afterwards, and " +
+			"destroying the resource only removes it from the state. Two Step Verification resides under the " +
+			"`https://www.googleapis.com/auth/admin.directory.user.security` client scope.",
+
+		CreateContext: resourceTwoStepVerificationCreate,
+		ReadContext:   resourceTwoStepVerificationRead,
+		DeleteContext: resourceTwoStepVerificationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Description: "Identifies the user in the API request. The value can be the user's primary email " +
+					"address, alias email address, or unique user ID.",
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"triggers": {
+				Description: "Arbitrary map of values that, when changed, will turn off 2SV again.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"allow_enforced": {
+				Description: "If `false`, turning off 2SV fails for a user for whom 2SV is " +
+					"enforced, as the user has to enroll again at their next sign-in.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"turned_off": {
+				Description: "Whether 2SV was turned off. 2SV is only turned off if the user was enrolled.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"turned_off_at": {
+				Description: "The time when 2SV was turned off.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"is_enrolled_in_2_step_verification": {
+				Description: "Is enrolled in 2-step verification.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"is_enforced_in_2_step_verification": {
+				Description: "Is 2-step verification enforced.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceTwoStepVerificationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	log.Printf("[DEBUG] Creating Two Step Verification for User %q", userId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	twoStepVerificationService, diags := GetTwoStepVerificationService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	user, err := usersService.Get(userId).Fields("id", "primaryEmail", "isEnrolledIn2Sv", "isEnforcedIn2Sv").Do()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if user.IsEnforcedIn2Sv && !d.Get("allow_enforced").(bool) {
+		return diag.Errorf("2-step verification is enforced for user %s, so they will have to enroll again at "+
+			"their next sign-in, set allow_enforced to true to turn it off anyway", user.PrimaryEmail)
+	}
+
+	turnedOff := false
+	if user.IsEnrolledIn2Sv {
+		err = twoStepVerificationService.TurnOff(userId).Do()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		turnedOff = true
+	} else {
+		log.Printf("[DEBUG] User %q is not enrolled in 2-step verification", userId)
+	}
+
+	d.SetId(user.Id)
+	d.Set("turned_off", turnedOff)
+	if turnedOff {
+		d.Set("turned_off_at", time.Now().Format(time.RFC3339))
+	}
+
+	log.Printf("[DEBUG] Finished creating Two Step Verification %q for User %q", d.Id(), userId)
+
+	return resourceTwoStepVerificationRead(ctx, d, meta)
+}
+
+func resourceTwoStepVerificationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	log.Printf("[DEBUG] Getting Two Step Verification for User %q", userId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	user, err := usersService.Get(userId).Fields("id", "isEnrolledIn2Sv", "isEnforcedIn2Sv").Do()
+	if err != nil {
+		return handleNotFoundError(err, d, userId)
+	}
+
+	// enrolling again is expected, so the status is only reported and does not trigger turning 2SV off again
+	d.Set("is_enrolled_in_2_step_verification", user.IsEnrolledIn2Sv)
+	d.Set("is_enforced_in_2_step_verification", user.IsEnforcedIn2Sv)
+
+	log.Printf("[DEBUG] Finished getting Two Step Verification for User %q", userId)
+
+	return diags
+}
+
+func resourceTwoStepVerificationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// 2SV can only be turned on by the user, we just remove from Terraform state
+	log.Printf("[DEBUG] Removing Two Step Verification %q from state", d.Id())
+
+	d.SetId("")
+
+	return diags
+}
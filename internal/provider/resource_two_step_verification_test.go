@@ -0,0 +1,93 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// newFake2svDirectoryClient returns a client for a fake Directory API with a user enrolled in 2SV,
+// for whom 2SV is enforced if enforced is true, and a func returning the requests received.
+func newFake2svDirectoryClient(t *testing.T, enforced bool) (*apiClient, func() []string) {
+	enrolled := true
+
+	return newFakeApiServer(t, "/admin/directory/v1", func(w http.ResponseWriter, r *http.Request, path string) {
+		switch {
+		case r.Method == http.MethodGet && path == "/users/jane@example.com":
+			fmt.Fprintf(w, `{"id": "123", "primaryEmail": "jane@example.com", "isEnrolledIn2Sv": %t, "isEnforcedIn2Sv": %t}`,
+				enrolled, enforced)
+		case r.Method == http.MethodPost && path == "/users/jane@example.com/twoStepVerification/turnOff":
+			enrolled = false
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "Resource Not Found: userKey"}}`)
+		}
+	})
+}
+
+func TestResourceTwoStepVerificationCreate(t *testing.T) {
+	client, requests := newFake2svDirectoryClient(t, false)
+
+	d := schema.TestResourceDataRaw(t, resourceTwoStepVerification().Schema, map[string]interface{}{
+		"user_id": "jane@example.com",
+	})
+
+	diags := resourceTwoStepVerificationCreate(context.Background(), d, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expectedRequests := []string{
+		"GET /users/jane@example.com",
+		"POST /users/jane@example.com/twoStepVerification/turnOff",
+		"GET /users/jane@example.com",
+	}
+	if actual := requests(); !reflect.DeepEqual(actual, expectedRequests) {
+		t.Errorf("expected requests %v, got %v", expectedRequests, actual)
+	}
+
+	if d.Id() != "123" || !d.Get("turned_off").(bool) || d.Get("turned_off_at").(string) == "" {
+		t.Errorf("expected 2SV to be recorded as turned off, got id %q, turned_off %t", d.Id(), d.Get("turned_off"))
+	}
+
+	if d.Get("is_enrolled_in_2_step_verification").(bool) {
+		t.Errorf("expected the user to no longer be enrolled")
+	}
+}
+
+func TestResourceTwoStepVerificationCreate_enforced(t *testing.T) {
+	client, requests := newFake2svDirectoryClient(t, true)
+
+	d := schema.TestResourceDataRaw(t, resourceTwoStepVerification().Schema, map[string]interface{}{
+		"user_id": "jane@example.com",
+	})
+
+	diags := resourceTwoStepVerificationCreate(context.Background(), d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "allow_enforced") {
+		t.Fatalf("expected an enforcement error, got %v", diags)
+	}
+
+	if actual := requests(); len(actual) != 1 {
+		t.Errorf("expected 2SV to not be turned off, got requests %v", actual)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceTwoStepVerification().Schema, map[string]interface{}{
+		"user_id":        "jane@example.com",
+		"allow_enforced": true,
+	})
+
+	diags = resourceTwoStepVerificationCreate(context.Background(), d, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if !d.Get("turned_off").(bool) {
+		t.Errorf("expected 2SV to be turned off when allow_enforced is true")
+	}
+}
@@ -8,12 +8,14 @@ import (
 	"net/mail"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	datatransfer "google.golang.org/api/admin/datatransfer/v1"
 	directory "google.golang.org/api/admin/directory/v1"
 	"google.golang.org/api/googleapi"
 )
@@ -85,6 +87,21 @@ func diffSuppressCustomSchemas(_, _, _ string, d *schema.ResourceData) bool {
 	return reflect.DeepEqual(oldMap, newMap)
 }
 
+// diffSuppressWriteOnlyPassword compares the configured password against the salted hash stored in
+// the state when password_write_only is set, or ignores it entirely when password_version is set.
+func diffSuppressWriteOnlyPassword(_, old, new string, d *schema.ResourceData) bool {
+	if !d.Get("password_write_only").(bool) {
+		return false
+	}
+
+	if d.Get("password_version").(string) != "" {
+		return true
+	}
+
+	// a plaintext password stored before password_write_only was set is hashed on the next apply
+	return old == new || passwordMatchesStateHash(old, new)
+}
+
 func transformCustomSchemasTo2DMap(customSchemas []interface{}) map[string]map[string]string {
 	result := make(map[string]map[string]string)
 	for _, schema := range customSchemas {
@@ -121,9 +138,12 @@ func resourceUser() *schema.Resource {
 		UpdateContext: resourceUserUpdate,
 		DeleteContext: resourceUserDelete,
 
+		CustomizeDiff: resourceUserCustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
 			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 
 		Importer: &schema.ResourceImporter{
@@ -146,12 +166,28 @@ func resourceUser() *schema.Resource {
 				Description: "Stores the password for the user account. A password can contain any combination of " +
 					"ASCII characters. A minimum of 8 characters is required. The maximum length is 100 characters. " +
 					"As the API does not return the value of password, this field is write-only, and the value stored " +
-					"in the state will be what is provided in the configuration. The field is required on create and will " +
-					"be empty on import.",
+					"in the state will be what is provided in the configuration, unless `password_write_only` is set. " +
+					"The field is required on create and will be empty on import.",
 				Type:             schema.TypeString,
 				Optional:         true,
 				Sensitive:        true,
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(8, 100)),
+				DiffSuppressFunc: diffSuppressWriteOnlyPassword,
+			},
+			"password_write_only": {
+				Description: "If true, the plaintext `password` is never stored in the state. A salted hash of the " +
+					"password is stored instead and used to detect changes, or nothing at all when `password_version` " +
+					"is set.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"password_version": {
+				Description: "An arbitrary value, such as a counter or a date, used in place of the password to detect " +
+					"changes when `password_write_only` is true. The password is then only sent when the user is " +
+					"created or `password_version` changes.",
+				Type:     schema.TypeString,
+				Optional: true,
 			},
 			"hash_function": {
 				Description: "Stores the hash format of the password property. We recommend sending the password " +
@@ -160,6 +196,15 @@ func resourceUser() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"hash_password": {
+				Description: "If true, `password` is provided in plaintext and hashed by the provider with " +
+					"`hash_function` before it is sent, so that only the hash leaves the machine. `MD5` and `SHA-1` " +
+					"are hex-encoded, and `crypt` uses a salted SHA-512 hash.",
+				Type:         schema.TypeBool,
+				Optional:     true,
+				Default:      false,
+				RequiredWith: []string{"hash_function"},
+			},
 			"is_admin": {
 				Description: "Indicates a user with super admininistrator privileges.",
 				Type:        schema.TypeBool,
@@ -351,6 +396,13 @@ func resourceUser() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"manage_aliases": {
+				Description: "If `false`, `aliases` are neither read nor written, so they can be " +
+					"managed with `googleworkspace_user_alias` resources instead.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
 			"is_mailbox_setup": {
 				Description: "Indicates if the user's Google mailbox is created. This property is only applicable " +
 					"if the user has been assigned a Gmail license.",
@@ -964,6 +1016,50 @@ func resourceUser() *schema.Resource {
 					},
 				},
 			},
+			"managed_attributes": {
+				Description: "An allow-list of the attributes managed by this resource. Attributes that are not " +
+					"listed are only sent when the user is created, are never updated, and changes made to them " +
+					"outside of Terraform are not reported as drift. Can be any of " + userOwnableAttributesDescription + ".",
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"unmanaged_attributes"},
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(userOwnableAttributes, false)),
+				},
+			},
+			"unmanaged_attributes": {
+				Description: "A deny-list of the attributes that are not managed by this resource, for example because " +
+					"they are written by Google Cloud Directory Sync or an HR connector. These attributes are only sent " +
+					"when the user is created, are never updated, and changes made to them outside of Terraform are " +
+					"not reported as drift. Can be any of " + userOwnableAttributesDescription + ".",
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"managed_attributes"},
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(userOwnableAttributes, false)),
+				},
+			},
+			"owned_attributes": {
+				Description: "The attributes managed by this resource, according to `managed_attributes` and " +
+					"`unmanaged_attributes`.",
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ignored_custom_schemas": {
+				Description: "Names of custom schemas whose values are managed outside of this resource, for example " +
+					"with `googleworkspace_user_custom_schema_values`. Their values are neither read into nor written " +
+					"from `custom_schemas`.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"is_enrolled_in_2_step_verification": {
 				Description: "Is enrolled in 2-step verification.",
 				Type:        schema.TypeBool,
@@ -997,17 +1093,96 @@ func resourceUser() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"deletion_policy": {
+				Description: "What happens to the user when the resource is destroyed. `DELETE` deletes the user, " +
+					"`SUSPEND` suspends the user, `ARCHIVE` archives the user (this requires an Archived User license) " +
+					"and `TRANSFER_THEN_DELETE` transfers the ownership of the user's Drive and Calendar data to " +
+					"`transfer_recipient`, waits for the transfer to complete and then deletes the user. A suspended or " +
+					"archived user is only removed from the state. `TRANSFER_THEN_DELETE` requires the " +
+					"`https://www.googleapis.com/auth/admin.datatransfer` client scope, and a data transfer to the recipient " +
+					"that is in progress or completed is resumed rather than started again. The policy must be applied before " +
+					"the resource is destroyed to take effect.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "DELETE",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"DELETE", "SUSPEND",
+					"ARCHIVE", "TRANSFER_THEN_DELETE"}, false)),
+			},
+			"restore_if_deleted": {
+				Description: "If true, a user with the same `primary_email` that was deleted within the last 20 days " +
+					"is restored into `org_unit_path` on create, and the rest of the configuration is applied to it, " +
+					"instead of creating a new user.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"transfer_recipient": {
+				Description: "The email or immutable ID of the user receiving the Drive and Calendar data when " +
+					"`deletion_policy` is `TRANSFER_THEN_DELETE`, which requires it. The user must exist when planning.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 		},
 	}
 }
 
+func resourceUserCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if !d.NewValueKnown("deletion_policy") || !d.NewValueKnown("transfer_recipient") {
+		return nil
+	}
+
+	// only look the recipient up when it's set or changed, rather than on every plan
+	if !d.HasChange("deletion_policy") && !d.HasChange("transfer_recipient") {
+		return nil
+	}
+
+	return validateUserDeletionPolicy(d.Get("deletion_policy").(string), d.Get("transfer_recipient").(string), func(recipient string) error {
+		client := meta.(*apiClient)
+
+		directoryService, diags := client.NewDirectoryService()
+		if diags.HasError() {
+			return fmt.Errorf("%s", diags[0].Summary)
+		}
+
+		usersService, diags := GetUsersService(directoryService)
+		if diags.HasError() {
+			return fmt.Errorf("%s", diags[0].Summary)
+		}
+
+		_, err := usersService.Get(recipient).Fields("id").Context(ctx).Do()
+		return err
+	})
+}
+
+// validateUserDeletionPolicy returns an error if the deletion policy transfers the user's data, but the
+// transfer recipient is unset or can't be found.
+func validateUserDeletionPolicy(policy, recipient string, getRecipient func(string) error) error {
+	if policy != "TRANSFER_THEN_DELETE" {
+		return nil
+	}
+
+	if recipient == "" {
+		return fmt.Errorf("transfer_recipient is required when deletion_policy is TRANSFER_THEN_DELETE")
+	}
+
+	if err := getRecipient(recipient); err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("transfer_recipient %s was not found", recipient)
+		}
+		return fmt.Errorf("error retrieving transfer_recipient %s: %s", recipient, err)
+	}
+
+	return nil
+}
+
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	// use the meta value to retrieve your client from the provider configure method
 	client := meta.(*apiClient)
 
-	if d.Get("password").(string) == "" {
+	password := configuredUserPassword(d)
+	if password == "" {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Password is required when creating a new user",
@@ -1019,6 +1194,11 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta any) d
 	primaryEmail := d.Get("primary_email").(string)
 	log.Printf("[DEBUG] Creating User %q: %#v", d.Id(), primaryEmail)
 
+	password, hashFunction, diags := expandUserPassword(d, password)
+	if diags.HasError() {
+		return diags
+	}
+
 	directoryService, diags := client.NewDirectoryService()
 	if diags.HasError() {
 		return diags
@@ -1031,8 +1211,8 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta any) d
 
 	userObj := directory.User{
 		PrimaryEmail:               primaryEmail,
-		Password:                   d.Get("password").(string),
-		HashFunction:               d.Get("hash_function").(string),
+		Password:                   password,
+		HashFunction:               hashFunction,
 		Suspended:                  d.Get("suspended").(bool),
 		ChangePasswordAtNextLogin:  d.Get("change_password_at_next_login").(bool),
 		IpWhitelisted:              d.Get("ip_allowlist").(bool),
@@ -1073,21 +1253,53 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta any) d
 		userObj.CustomSchemas = customSchemas
 	}
 
-	user, err := usersService.Insert(&userObj).Do()
-	if err != nil {
-		return diag.FromErr(err)
+	var user *directory.User
+	if d.Get("restore_if_deleted").(bool) {
+		var deletedUsers []*directory.User
+		err := usersService.List().Customer(client.Customer).ShowDeleted("true").
+			Fields("nextPageToken", "users(id,primaryEmail,deletionTime)").
+			Pages(ctx, func(resp *directory.Users) error {
+				deletedUsers = append(deletedUsers, resp.Users...)
+
+				return nil
+			})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		user = findDeletedUser(deletedUsers, primaryEmail)
+		if user != nil {
+			orgUnitPath := userObj.OrgUnitPath
+			if orgUnitPath == "" {
+				orgUnitPath = "/"
+			}
+
+			log.Printf("[DEBUG] Restoring deleted User %q into %q: %#v", user.Id, orgUnitPath, primaryEmail)
+			err = usersService.Undelete(user.Id, &directory.UserUndelete{OrgUnitPath: orgUnitPath}).Do()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if user == nil {
+		var err error
+		user, err = usersService.Insert(&userObj).Do()
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	d.SetId(user.Id)
 
-	// INSERT will respond with the User that will be created, however, it is eventually consistent
+	// INSERT (or UNDELETE) will respond with the User that will be created, however, it is eventually consistent
 	// After INSERT, the etag is updated along with the User (and any aliases),
 	// once we get a consistent etag, we can feel confident that our User is also consistent
 	cc := consistencyCheck{
 		resourceType: "user",
 		timeout:      d.Timeout(schema.TimeoutCreate),
 	}
-	err = retryTimeDuration(ctx, d.Timeout(schema.TimeoutCreate), func() error {
+	err := retryTimeDuration(ctx, d.Timeout(schema.TimeoutCreate), func() error {
 		var retryErr error
 
 		if cc.reachedConsistency(1) {
@@ -1123,6 +1335,13 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta any) d
 }
 
 func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readUser(ctx, d, meta, true)
+}
+
+// readUser reads the user into the state. The arguments that control what the resource manages
+// don't exist in the user data source, which shares this function, so they're only handled when
+// isResource is set.
+func readUser(ctx context.Context, d *schema.ResourceData, meta interface{}, isResource bool) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	// use the meta value to retrieve your client from the provider configure method
@@ -1155,6 +1374,14 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta interfac
 		return diags
 	}
 
+	// schemas managed outside of this resource are not read into custom_schemas
+	if isResource {
+		ignoredCustomSchemas := listOfInterfacestoStrings(d.Get("ignored_custom_schemas").([]interface{}))
+		for _, schemaName := range ignoredCustomSchemas {
+			delete(user.CustomSchemas, schemaName)
+		}
+	}
+
 	customSchemas := []map[string]interface{}{}
 	if len(user.CustomSchemas) > 0 {
 		customSchemas, diags = flattenCustomSchemas(user.CustomSchemas, client)
@@ -1163,10 +1390,37 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta interfac
 		}
 	}
 
+	// manage_aliases is not returned in the response, so default it on import
+	if _, ok := d.GetOkExists("manage_aliases"); !ok && isResource {
+		d.Set("manage_aliases", true)
+	}
+
+	// attributes that are not owned by this resource are left as they are in the state, so that
+	// changes made by other tools are not reported as drift
+	setOwned := func(key string, value interface{}) {
+		if !isResource || userAttributeOwned(d, key) {
+			d.Set(key, value)
+		}
+	}
+
 	d.Set("primary_email", user.PrimaryEmail)
 	// password and hash_function are not returned in the response, so set them to what we defined in the config
-	d.Set("password", d.Get("password"))
+	if isResource && d.Get("password_write_only").(bool) {
+		// replaces a plaintext password left in the state before password_write_only was set
+		passwordState, err := writeOnlyPasswordState(d.Get("password").(string), d.Get("password_version").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("password", passwordState)
+	} else {
+		d.Set("password", d.Get("password"))
+	}
 	d.Set("hash_function", d.Get("hash_function"))
+	if isResource {
+		d.Set("password_write_only", d.Get("password_write_only"))
+		d.Set("password_version", d.Get("password_version"))
+		d.Set("hash_password", d.Get("hash_password"))
+	}
 	d.Set("is_admin", user.IsAdmin)
 	d.Set("is_delegated_admin", user.IsDelegatedAdmin)
 	d.Set("agreed_to_terms", user.AgreedToTerms)
@@ -1174,38 +1428,48 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta interfac
 	d.Set("change_password_at_next_login", user.ChangePasswordAtNextLogin)
 	d.Set("ip_allowlist", user.IpWhitelisted)
 	d.Set("name", flattenName(user.Name))
-	d.Set("emails", flattenInterfaceObjects(user.Emails))
-	d.Set("external_ids", flattenInterfaceObjects(user.ExternalIds))
-	d.Set("relations", flattenInterfaceObjects(user.Relations))
+	setOwned("emails", flattenInterfaceObjects(user.Emails))
+	setOwned("external_ids", flattenInterfaceObjects(user.ExternalIds))
+	setOwned("relations", flattenInterfaceObjects(user.Relations))
 	d.Set("etag", user.Etag)
-	d.Set("aliases", user.Aliases)
+	setOwned("aliases", user.Aliases)
 	d.Set("is_mailbox_setup", user.IsMailboxSetup)
 	d.Set("customer_id", user.CustomerId)
-	d.Set("addresses", flattenInterfaceObjects(user.Addresses))
-	d.Set("organizations", flattenInterfaceObjects(user.Organizations))
+	setOwned("addresses", flattenInterfaceObjects(user.Addresses))
+	setOwned("organizations", flattenInterfaceObjects(user.Organizations))
 	d.Set("last_login_time", user.LastLoginTime)
-	d.Set("phones", flattenInterfaceObjects(user.Phones))
+	setOwned("phones", flattenInterfaceObjects(user.Phones))
 	d.Set("suspension_reason", user.SuspensionReason)
 	d.Set("thumbnail_photo_url", user.ThumbnailPhotoUrl)
-	d.Set("languages", flattenInterfaceObjects(user.Languages))
-	d.Set("posix_accounts", flattenInterfaceObjects(user.PosixAccounts))
+	setOwned("languages", flattenInterfaceObjects(user.Languages))
+	setOwned("posix_accounts", flattenInterfaceObjects(user.PosixAccounts))
 	d.Set("creation_time", user.CreationTime)
 	d.Set("non_editable_aliases", user.NonEditableAliases)
-	d.Set("ssh_public_keys", flattenInterfaceObjects(user.SshPublicKeys))
-	d.Set("websites", flattenInterfaceObjects(user.Websites))
-	d.Set("locations", flattenInterfaceObjects(user.Locations))
+	setOwned("ssh_public_keys", flattenInterfaceObjects(user.SshPublicKeys))
+	setOwned("websites", flattenInterfaceObjects(user.Websites))
+	setOwned("locations", flattenInterfaceObjects(user.Locations))
 	d.Set("include_in_global_address_list", user.IncludeInGlobalAddressList)
-	d.Set("keywords", flattenInterfaceObjects(user.Keywords))
+	setOwned("keywords", flattenInterfaceObjects(user.Keywords))
 	d.Set("deletion_time", user.DeletionTime)
 	d.Set("thumbnail_photo_etag", user.ThumbnailPhotoEtag)
-	d.Set("ims", flattenInterfaceObjects(user.Ims))
-	d.Set("custom_schemas", customSchemas)
+	setOwned("ims", flattenInterfaceObjects(user.Ims))
+	setOwned("custom_schemas", customSchemas)
 	d.Set("is_enrolled_in_2_step_verification", user.IsEnrolledIn2Sv)
 	d.Set("is_enforced_in_2_step_verification", user.IsEnforcedIn2Sv)
 	d.Set("archived", user.Archived)
-	d.Set("org_unit_path", user.OrgUnitPath)
-	d.Set("recovery_email", user.RecoveryEmail)
-	d.Set("recovery_phone", user.RecoveryPhone)
+	setOwned("org_unit_path", user.OrgUnitPath)
+	setOwned("recovery_email", user.RecoveryEmail)
+	setOwned("recovery_phone", user.RecoveryPhone)
+
+	if isResource {
+		// deletion_policy and restore_if_deleted are not returned in the response, so default them on import
+		if d.Get("deletion_policy").(string) == "" {
+			d.Set("deletion_policy", "DELETE")
+		}
+		d.Set("restore_if_deleted", d.Get("restore_if_deleted"))
+
+		d.Set("owned_attributes", ownedUserAttributes(d))
+	}
 
 	d.SetId(user.Id)
 	log.Printf("[DEBUG] Finished getting User %q: %#v", d.Id(), primaryEmail)
@@ -1232,6 +1496,11 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 		return diags
 	}
 
+	// attributes that are not owned by this resource are only sent when the user is created
+	sendOwned := func(key string) bool {
+		return d.IsNewResource() || userAttributeOwned(d, key)
+	}
+
 	userObj := directory.User{}
 	forceSendFields := []string{}
 
@@ -1241,12 +1510,28 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 		userObj.PrimaryEmail = primaryEmail
 	}
 
-	if d.HasChange("password") {
-		userObj.Password = d.Get("password").(string)
+	// when the password is only tracked by password_version, the password itself never shows a change
+	sendPassword := d.HasChange("password")
+	if d.Get("password_write_only").(bool) && d.Get("password_version").(string) != "" {
+		sendPassword = d.HasChange("password_version")
+	}
+
+	if sendPassword {
+		password, hashFunction, diags := expandUserPassword(d, configuredUserPassword(d))
+		if diags.HasError() {
+			return diags
+		}
+
+		userObj.Password = password
 
 		if userObj.Password == "" {
 			forceSendFields = append(forceSendFields, "Password")
 		}
+
+		// a hashed password is only accepted along with its hash function
+		if d.Get("hash_password").(bool) {
+			userObj.HashFunction = hashFunction
+		}
 	}
 
 	if d.HasChange("hash_function") {
@@ -1257,11 +1542,11 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 		}
 	}
 
-	if d.HasChange("org_unit_path") {
+	if d.HasChange("org_unit_path") && sendOwned("org_unit_path") {
 		userObj.OrgUnitPath = d.Get("org_unit_path").(string)
 	}
 
-	if d.HasChange("recovery_email") {
+	if d.HasChange("recovery_email") && sendOwned("recovery_email") {
 		userObj.RecoveryEmail = d.Get("recovery_email").(string)
 
 		if userObj.RecoveryEmail == "" {
@@ -1269,7 +1554,7 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 		}
 	}
 
-	if d.HasChange("recovery_phone") {
+	if d.HasChange("recovery_phone") && sendOwned("recovery_phone") {
 		userObj.RecoveryPhone = d.Get("recovery_phone").(string)
 
 		if userObj.RecoveryPhone == "" {
@@ -1312,72 +1597,72 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 		userObj.Name = expandName(d.Get("name"))
 	}
 
-	if d.HasChange("emails") {
+	if d.HasChange("emails") && sendOwned("emails") {
 		emails := expandInterfaceObjects(d.Get("emails"))
 		userObj.Emails = emails
 	}
 
-	if d.HasChange("external_ids") {
+	if d.HasChange("external_ids") && sendOwned("external_ids") {
 		externalIds := expandInterfaceObjects(d.Get("external_ids"))
 		userObj.ExternalIds = externalIds
 	}
 
-	if d.HasChange("relations") {
+	if d.HasChange("relations") && sendOwned("relations") {
 		emails := expandInterfaceObjects(d.Get("relations"))
 		userObj.Relations = emails
 	}
 
-	if d.HasChange("addresses") {
+	if d.HasChange("addresses") && sendOwned("addresses") {
 		addresses := expandInterfaceObjects(d.Get("addresses"))
 		userObj.Addresses = addresses
 	}
 
-	if d.HasChange("organizations") {
+	if d.HasChange("organizations") && sendOwned("organizations") {
 		organizations := expandInterfaceObjects(d.Get("organizations"))
 		userObj.Organizations = organizations
 	}
 
-	if d.HasChange("phones") {
+	if d.HasChange("phones") && sendOwned("phones") {
 		phones := expandInterfaceObjects(d.Get("phones"))
 		userObj.Phones = phones
 	}
 
-	if d.HasChange("languages") {
+	if d.HasChange("languages") && sendOwned("languages") {
 		languages := expandInterfaceObjects(d.Get("languages"))
 		userObj.Languages = languages
 	}
 
-	if d.HasChange("posix_accounts") {
+	if d.HasChange("posix_accounts") && sendOwned("posix_accounts") {
 		posixAccounts := expandInterfaceObjects(d.Get("posix_accounts"))
 		userObj.PosixAccounts = posixAccounts
 	}
 
-	if d.HasChange("ssh_public_keys") {
+	if d.HasChange("ssh_public_keys") && sendOwned("ssh_public_keys") {
 		sshPublicKeys := expandInterfaceObjects(d.Get("ssh_public_keys"))
 		userObj.SshPublicKeys = sshPublicKeys
 	}
 
-	if d.HasChange("websites") {
+	if d.HasChange("websites") && sendOwned("websites") {
 		websites := expandInterfaceObjects(d.Get("websites"))
 		userObj.Websites = websites
 	}
 
-	if d.HasChange("locations") {
+	if d.HasChange("locations") && sendOwned("locations") {
 		locations := expandInterfaceObjects(d.Get("locations"))
 		userObj.Locations = locations
 	}
 
-	if d.HasChange("keywords") {
+	if d.HasChange("keywords") && sendOwned("keywords") {
 		keywords := expandInterfaceObjects(d.Get("keywords"))
 		userObj.Keywords = keywords
 	}
 
-	if d.HasChange("ims") {
+	if d.HasChange("ims") && sendOwned("ims") {
 		ims := expandInterfaceObjects(d.Get("ims"))
 		userObj.Ims = ims
 	}
 
-	if d.HasChange("custom_schemas") {
+	if d.HasChange("custom_schemas") && sendOwned("custom_schemas") {
 		if len(d.Get("custom_schemas").([]interface{})) > 0 {
 			diags = validateCustomSchemas(d, client)
 			if diags.HasError() {
@@ -1394,7 +1679,7 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 	}
 
 	numInserts := 0
-	if d.HasChange("aliases") {
+	if d.HasChange("aliases") && sendOwned("aliases") {
 		old, new := d.GetChange("aliases")
 		oldAliases := listOfInterfacestoStrings(old.([]interface{}))
 		newAliases := listOfInterfacestoStrings(new.([]interface{}))
@@ -1498,7 +1783,8 @@ func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta interf
 	client := meta.(*apiClient)
 
 	primaryEmail := d.Get("primary_email").(string)
-	log.Printf("[DEBUG] Deleting User %q: %#v", d.Id(), primaryEmail)
+	deletionPolicy := d.Get("deletion_policy").(string)
+	log.Printf("[DEBUG] Deleting User %q (deletion policy %s): %#v", d.Id(), deletionPolicy, primaryEmail)
 
 	directoryService, diags := client.NewDirectoryService()
 	if diags.HasError() {
@@ -1510,6 +1796,34 @@ func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta interf
 		return diags
 	}
 
+	switch deletionPolicy {
+	case "SUSPEND", "ARCHIVE":
+		// both are idempotent, so an interrupted destroy can simply be run again
+		userObj := directory.User{}
+		if deletionPolicy == "SUSPEND" {
+			userObj.Suspended = true
+			userObj.ForceSendFields = []string{"Suspended"}
+		} else {
+			userObj.Archived = true
+			userObj.ForceSendFields = []string{"Archived"}
+		}
+
+		_, err := usersService.Update(d.Id(), &userObj).Do()
+		if err != nil {
+			return handleNotFoundError(err, d, primaryEmail)
+		}
+
+		log.Printf("[DEBUG] Finished applying deletion policy %s to User %q: %#v", deletionPolicy, d.Id(), primaryEmail)
+		d.SetId("")
+
+		return diags
+	case "TRANSFER_THEN_DELETE":
+		diags = transferUserData(ctx, d, client, usersService)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
 	err := usersService.Delete(d.Id()).Do()
 	if err != nil {
 		return handleNotFoundError(err, d, primaryEmail)
@@ -1520,6 +1834,296 @@ func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta interf
 	return diags
 }
 
+// userOwnableAttributes are the attributes that can be left unmanaged with managed_attributes
+// or unmanaged_attributes. All other attributes are always managed.
+var userOwnableAttributes = []string{"addresses", "aliases", "custom_schemas", "emails", "external_ids", "ims",
+	"keywords", "languages", "locations", "org_unit_path", "organizations", "phones", "posix_accounts",
+	"recovery_email", "recovery_phone", "relations", "ssh_public_keys", "websites"}
+
+var userOwnableAttributesDescription = "`" + strings.Join(userOwnableAttributes, "`, `") + "`"
+
+// userAttributeOwned returns whether the attribute is managed by the resource.
+func userAttributeOwned(d *schema.ResourceData, attr string) bool {
+	if attr == "aliases" && !d.Get("manage_aliases").(bool) {
+		return false
+	}
+
+	managed := listOfInterfacestoStrings(d.Get("managed_attributes").(*schema.Set).List())
+	unmanaged := listOfInterfacestoStrings(d.Get("unmanaged_attributes").(*schema.Set).List())
+
+	return isUserAttributeOwned(managed, unmanaged, attr)
+}
+
+func isUserAttributeOwned(managed, unmanaged []string, attr string) bool {
+	if !stringInSlice(userOwnableAttributes, attr) {
+		return true
+	}
+
+	if len(managed) > 0 {
+		return stringInSlice(managed, attr)
+	}
+
+	return !stringInSlice(unmanaged, attr)
+}
+
+func ownedUserAttributes(d *schema.ResourceData) []string {
+	var result []string
+	for _, attr := range userOwnableAttributes {
+		if userAttributeOwned(d, attr) {
+			result = append(result, attr)
+		}
+	}
+
+	return result
+}
+
+// configuredUserPassword returns the password from the configuration, as the planned value
+// is suppressed when the password is only tracked by password_version.
+func configuredUserPassword(d *schema.ResourceData) string {
+	rawConfig := d.GetRawConfig()
+	if !rawConfig.IsNull() && rawConfig.IsKnown() {
+		password := rawConfig.GetAttr("password")
+		if password.IsKnown() && !password.IsNull() {
+			return password.AsString()
+		}
+	}
+
+	password := d.Get("password").(string)
+	if isPasswordStateHash(password) {
+		return ""
+	}
+
+	return password
+}
+
+// expandUserPassword returns the password and hash function to send to the API, hashing the
+// password when hash_password is set. When password_write_only is set, the value stored in the
+// state for password is replaced before any request is made, so that the plaintext password is
+// never persisted, even if the request fails.
+func expandUserPassword(d *schema.ResourceData, password string) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if d.Get("password_write_only").(bool) {
+		passwordState, err := writeOnlyPasswordState(password, d.Get("password_version").(string))
+		if err != nil {
+			return "", "", diag.FromErr(err)
+		}
+		d.Set("password", passwordState)
+	}
+
+	hashFunction := d.Get("hash_function").(string)
+	if password == "" || !d.Get("hash_password").(bool) {
+		return password, hashFunction, diags
+	}
+
+	hashed, err := hashUserPassword(password, hashFunction)
+	if err != nil {
+		return "", "", diag.FromErr(err)
+	}
+
+	return hashed, hashFunction, diags
+}
+
+// writeOnlyPasswordState returns the value stored in the state for password when password_write_only
+// is set: nothing when password_version is set, otherwise a salted hash of the password.
+func writeOnlyPasswordState(password, passwordVersion string) (string, error) {
+	if passwordVersion != "" || password == "" {
+		return "", nil
+	}
+
+	if isPasswordStateHash(password) {
+		return password, nil
+	}
+
+	return hashPasswordForState(password)
+}
+
+// findDeletedUser returns the most recently deleted user with the given primary email.
+func findDeletedUser(deletedUsers []*directory.User, primaryEmail string) *directory.User {
+	var result *directory.User
+	for _, user := range deletedUsers {
+		if !strings.EqualFold(user.PrimaryEmail, primaryEmail) {
+			continue
+		}
+
+		// deletion times are in RFC 3339 format, so they can be compared as strings
+		if result == nil || user.DeletionTime > result.DeletionTime {
+			result = user
+		}
+	}
+
+	return result
+}
+
+// userDataTransferParams are the transfer parameters used for each application when
+// transferring a user's data before deletion, keyed by application name.
+var userDataTransferParams = map[string][]*datatransfer.ApplicationTransferParam{
+	"Drive and Docs": {
+		{
+			Key:   "PRIVACY_LEVEL",
+			Value: []string{"PRIVATE", "SHARED"},
+		},
+	},
+	"Calendar": {
+		{
+			Key:   "RELEASE_RESOURCES",
+			Value: []string{"TRUE"},
+		},
+	},
+}
+
+// transferUserData transfers the ownership of the user's Drive and Calendar data to the
+// transfer_recipient and waits for the transfer to complete. A transfer that is still in
+// progress from a previous, interrupted destroy is waited on instead of starting a new one, and
+// a completed one isn't started again.
+func transferUserData(ctx context.Context, d *schema.ResourceData, client *apiClient, usersService *directory.UsersService) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	recipient := d.Get("transfer_recipient").(string)
+	if recipient == "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "transfer_recipient is required when deletion_policy is TRANSFER_THEN_DELETE",
+		})
+
+		return diags
+	}
+
+	recipientUser, err := usersService.Get(recipient).Fields("id").Do()
+	if err != nil {
+		return diag.Errorf("error retrieving transfer recipient %s: %s", recipient, err)
+	}
+
+	dataTransferService, diags := client.NewDataTransferService()
+	if diags.HasError() {
+		return diags
+	}
+
+	transfersService, diags := GetDataTransfersService(dataTransferService)
+	if diags.HasError() {
+		return diags
+	}
+
+	var transfers []*datatransfer.DataTransfer
+	err = transfersService.List().CustomerId(client.Customer).OldOwnerUserId(d.Id()).
+		NewOwnerUserId(recipientUser.Id).Pages(ctx, func(resp *datatransfer.DataTransfersListResponse) error {
+		transfers = append(transfers, resp.DataTransfers...)
+
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	transfer := findResumableDataTransfer(transfers)
+	if transfer != nil && transfer.OverallTransferStatusCode == "completed" {
+		log.Printf("[DEBUG] Data Transfer %q for User %q has already completed", transfer.Id, d.Id())
+		return diags
+	}
+
+	if transfer != nil {
+		log.Printf("[DEBUG] Resuming Data Transfer %q for User %q", transfer.Id, d.Id())
+	} else {
+		applicationsService, diags := GetDataTransferApplicationsService(dataTransferService)
+		if diags.HasError() {
+			return diags
+		}
+
+		var applications []*datatransfer.Application
+		err = applicationsService.List().CustomerId(client.Customer).Pages(ctx, func(resp *datatransfer.ApplicationsListResponse) error {
+			applications = append(applications, resp.Applications...)
+
+			return nil
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		transferObj, err := expandUserDataTransfer(applications, d.Id(), recipientUser.Id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		log.Printf("[DEBUG] Creating Data Transfer for User %q to %q", d.Id(), recipientUser.Id)
+		transfer, err = transfersService.Insert(transferObj).Do()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	err = retryTimeDuration(ctx, d.Timeout(schema.TimeoutDelete), func() error {
+		current, retryErr := transfersService.Get(transfer.Id).Do()
+		if retryErr != nil {
+			return retryErr
+		}
+
+		switch current.OverallTransferStatusCode {
+		case "completed":
+			return nil
+		case "failed":
+			return fmt.Errorf("data transfer %s for user %s failed", transfer.Id, d.Id())
+		}
+
+		return fmt.Errorf("timed out while waiting for data transfer %s to complete (status %q)", transfer.Id, current.OverallTransferStatusCode)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finished Data Transfer %q for User %q", transfer.Id, d.Id())
+
+	return diags
+}
+
+// findResumableDataTransfer returns the first completed transfer, so that an interrupted destroy
+// goes straight to the deletion, or otherwise the first transfer that is still in progress.
+func findResumableDataTransfer(transfers []*datatransfer.DataTransfer) *datatransfer.DataTransfer {
+	var pending *datatransfer.DataTransfer
+	for _, transfer := range transfers {
+		switch transfer.OverallTransferStatusCode {
+		case "completed":
+			return transfer
+		case "failed":
+			continue
+		}
+
+		if pending == nil {
+			pending = transfer
+		}
+	}
+
+	return pending
+}
+
+func expandUserDataTransfer(applications []*datatransfer.Application, oldOwnerUserId, newOwnerUserId string) (*datatransfer.DataTransfer, error) {
+	transfer := &datatransfer.DataTransfer{
+		OldOwnerUserId: oldOwnerUserId,
+		NewOwnerUserId: newOwnerUserId,
+	}
+
+	found := map[string]bool{}
+	for _, application := range applications {
+		params, ok := userDataTransferParams[application.Name]
+		if !ok || found[application.Name] {
+			continue
+		}
+		found[application.Name] = true
+
+		transfer.ApplicationDataTransfers = append(transfer.ApplicationDataTransfers, &datatransfer.ApplicationDataTransfer{
+			ApplicationId:             application.Id,
+			ApplicationTransferParams: params,
+		})
+	}
+
+	for name := range userDataTransferParams {
+		if !found[name] {
+			return nil, fmt.Errorf("data transfer application %q was not found for the customer", name)
+		}
+	}
+
+	return transfer, nil
+}
+
 // Expand functions
 
 func expandName(v interface{}) *directory.UserName {
@@ -1557,9 +2161,13 @@ func flattenName(nameObj *directory.UserName) interface{} {
 // Custom Schemas
 
 func validateCustomSchemas(d *schema.ResourceData, client *apiClient) diag.Diagnostics {
-	var diags diag.Diagnostics
-
-	new := d.Get("custom_schemas")
+	ignoredCustomSchemas := listOfInterfacestoStrings(d.Get("ignored_custom_schemas").([]interface{}))
+	for _, cs := range d.Get("custom_schemas").([]interface{}) {
+		schemaName := cs.(map[string]interface{})["schema_name"].(string)
+		if stringInSlice(ignoredCustomSchemas, schemaName) {
+			return diag.Errorf("custom schema %s cannot be configured in custom_schemas as it is listed in ignored_custom_schemas", schemaName)
+		}
+	}
 
 	directoryService, diags := client.NewDirectoryService()
 	if diags.HasError() {
@@ -1571,11 +2179,21 @@ func validateCustomSchemas(d *schema.ResourceData, client *apiClient) diag.Diagn
 		return diags
 	}
 
+	return validateCustomSchemaValues(d.Get("custom_schemas").([]interface{}), func(schemaName string) (*directory.Schema, error) {
+		return schemaService.Get(client.Customer, schemaName).Do()
+	})
+}
+
+// validateCustomSchemaValues validates a list of `custom_schemas` blocks against the
+// schema definitions returned by getSchema.
+func validateCustomSchemaValues(customSchemas []interface{}, getSchema func(schemaName string) (*directory.Schema, error)) diag.Diagnostics {
+	var diags diag.Diagnostics
+
 	// Validate config against schemas
-	for _, customSchema := range new.([]interface{}) {
+	for _, customSchema := range customSchemas {
 		schemaName := customSchema.(map[string]interface{})["schema_name"].(string)
 
-		schemaDef, err := schemaService.Get(client.Customer, schemaName).Do()
+		schemaDef, err := getSchema(schemaName)
 		if err != nil {
 			return diag.FromErr(err)
 		}
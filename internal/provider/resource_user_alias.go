@@ -0,0 +1,307 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func resourceUserAlias() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "User Alias resource manages a single alias of a Google Workspace User, without managing the " +
+			"user. Set `manage_aliases` to `false` on the `googleworkspace_user` to stop it from managing the same " +
+			"aliases. User Alias resides under the `https://www.googleapis.com/auth/admin.directory.user` client scope.",
+
+		CreateContext: resourceUserAliasCreate,
+		ReadContext:   resourceUserAliasRead,
+		DeleteContext: resourceUserAliasDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceUserAliasImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Description: "Identifies the user in the API request. The value can be the user's primary email " +
+					"address or unique user ID.",
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"alias": {
+				Description: "The alias email address.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceUserAliasCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	alias := d.Get("alias").(string)
+	log.Printf("[DEBUG] Creating User Alias %q for User %q", alias, userId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliasesService, diags := GetUserAliasService(usersService)
+	if diags.HasError() {
+		return diags
+	}
+
+	user, err := usersService.Get(userId).Fields("id", "primaryEmail").Do()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// an alias can only be attached to a single user or group
+	owner, err := findAliasOwner(directoryService, alias)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if owner != "" && !strings.EqualFold(owner, fmt.Sprintf("user %s", user.PrimaryEmail)) {
+		return diag.Errorf("alias %s is already attached to %s", alias, owner)
+	}
+
+	// an alias already attached to the user is adopted
+	if owner == "" {
+		_, err = aliasesService.Insert(user.Id, &directory.Alias{Alias: alias}).Do()
+		if isApiErrorWithCode(err, http.StatusConflict) {
+			return diag.Errorf("alias %s is already attached to another user or group", alias)
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("users/%s/aliases/%s", userId, alias))
+
+	// INSERT will respond with the Alias that will be created, however, it is eventually consistent
+	// After INSERT, the etag of the user's aliases is updated,
+	// once we get a consistent etag, we can feel confident that our Alias is also consistent
+	cc := consistencyCheck{
+		resourceType: "user alias",
+		timeout:      d.Timeout(schema.TimeoutCreate),
+	}
+	err = retryTimeDuration(ctx, d.Timeout(schema.TimeoutCreate), func() error {
+		var retryErr error
+
+		if cc.reachedConsistency(1) {
+			return nil
+		}
+
+		aliases, retryErr := aliasesService.List(user.Id).IfNoneMatch(cc.lastEtag).Do()
+		if googleapi.IsNotModified(retryErr) {
+			cc.currConsistent += 1
+		} else if retryErr != nil {
+			return fmt.Errorf("unexpected error during retries of %s: %s", cc.resourceType, retryErr)
+		} else if !aliasInList(flattenAliases(aliases), alias) {
+			// alias was not found yet therefore setting currConsistent back to null value
+			cc.currConsistent = 0
+		} else {
+			cc.handleNewEtag(aliases.Etag)
+		}
+
+		return fmt.Errorf("timed out while waiting for %s to be inserted", cc.resourceType)
+	})
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finished creating User Alias %q for User %q", alias, userId)
+
+	return resourceUserAliasRead(ctx, d, meta)
+}
+
+func resourceUserAliasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	alias := d.Get("alias").(string)
+	log.Printf("[DEBUG] Getting User Alias %q for User %q", alias, userId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliasesService, diags := GetUserAliasService(usersService)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliases, err := aliasesService.List(userId).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	if !aliasInList(flattenAliases(aliases), alias) {
+		log.Printf("[WARN] Removing User Alias %q because it's gone", d.Id())
+		d.SetId("")
+
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished getting User Alias %q for User %q", alias, userId)
+
+	return diags
+}
+
+func resourceUserAliasDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	alias := d.Get("alias").(string)
+	log.Printf("[DEBUG] Deleting User Alias %q from User %q", alias, userId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliasesService, diags := GetUserAliasService(usersService)
+	if diags.HasError() {
+		return diags
+	}
+
+	err := aliasesService.Delete(userId, alias).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	log.Printf("[DEBUG] Finished deleting User Alias %q from User %q", alias, userId)
+
+	return diags
+}
+
+func resourceUserAliasImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+
+	// id is of format "users/<user_id>/aliases/<alias>"
+	if len(parts) != 4 || parts[0] != "users" || parts[2] != "aliases" {
+		return nil, fmt.Errorf("user alias id (%s) is not of the correct format (users/<user_id>/aliases/<alias>)", d.Id())
+	}
+
+	d.Set("user_id", parts[1])
+	d.Set("alias", parts[3])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// findAliasOwner returns the user or group the email address belongs to, either as
+// a primary email or an alias, in the form "user <email>" or "group <email>". An empty
+// string is returned when the address is not in use.
+func findAliasOwner(directoryService *directory.Service, alias string) (string, error) {
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return "", fmt.Errorf("%s", diags[0].Summary)
+	}
+
+	user, err := usersService.Get(alias).Fields("primaryEmail").Do()
+	if err == nil {
+		return fmt.Sprintf("user %s", user.PrimaryEmail), nil
+	}
+	if !isNotFound(err) {
+		return "", err
+	}
+
+	groupsService, diags := GetGroupsService(directoryService)
+	if diags.HasError() {
+		return "", fmt.Errorf("%s", diags[0].Summary)
+	}
+
+	group, err := groupsService.Get(alias).Fields("email").Do()
+	if err == nil {
+		return fmt.Sprintf("group %s", group.Email), nil
+	}
+	if !isNotFound(err) {
+		return "", err
+	}
+
+	return "", nil
+}
+
+// flattenAliases returns the alias email addresses from an aliases list response.
+func flattenAliases(aliases *directory.Aliases) []string {
+	var result []string
+	if aliases == nil {
+		return result
+	}
+
+	for _, a := range aliases.Aliases {
+		aliasObj, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if alias, ok := aliasObj["alias"].(string); ok {
+			result = append(result, alias)
+		}
+	}
+
+	return result
+}
+
+// aliasInList returns whether the alias is in the list, ignoring case as email addresses
+// are case-insensitive.
+func aliasInList(aliases []string, alias string) bool {
+	for _, a := range aliases {
+		if strings.EqualFold(a, alias) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,110 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func TestFlattenAliases(t *testing.T) {
+	aliases := &directory.Aliases{
+		Aliases: []interface{}{
+			map[string]interface{}{"alias": "jane@example.com", "primaryEmail": "jdoe@example.com"},
+			map[string]interface{}{"alias": "j.doe@example.com"},
+			"unexpected",
+		},
+	}
+
+	expected := []string{"jane@example.com", "j.doe@example.com"}
+	if actual := flattenAliases(aliases); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	if actual := flattenAliases(nil); len(actual) != 0 {
+		t.Errorf("expected no aliases, got %v", actual)
+	}
+
+	if !aliasInList(expected, "Jane@Example.com") {
+		t.Errorf("expected aliases to be compared case-insensitively")
+	}
+}
+
+func TestResourceUserAliasImport(t *testing.T) {
+	d := resourceUserAlias().TestResourceData()
+	d.SetId("users/123456789/aliases/jane@example.com")
+
+	if _, err := resourceUserAliasImport(context.Background(), d, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if d.Get("user_id").(string) != "123456789" || d.Get("alias").(string) != "jane@example.com" {
+		t.Errorf("unexpected user_id %q and alias %q", d.Get("user_id"), d.Get("alias"))
+	}
+
+	d.SetId("groups/123456789/aliases/jane@example.com")
+	if _, err := resourceUserAliasImport(context.Background(), d, nil); err == nil {
+		t.Errorf("expected an error for a group alias id")
+	}
+}
+
+func TestAccResourceUserAlias_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testUserVals := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"password":   acctest.RandString(10),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceUserAlias_basic(testUserVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_user_alias.alias", "alias",
+						fmt.Sprintf("%s-alias@%s", testUserVals["userEmail"], domainName)),
+					resource.TestCheckResourceAttr("googleworkspace_user.user", "aliases.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_user_alias.alias",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceUserAlias_basic(testUserVals map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_user" "user" {
+  primary_email  = "%{userEmail}@%{domainName}"
+  password       = "%{password}"
+  manage_aliases = false
+
+  name {
+    family_name = "Scott"
+    given_name  = "Michael"
+  }
+}
+
+resource "googleworkspace_user_alias" "alias" {
+  user_id = googleworkspace_user.user.id
+  alias   = "%{userEmail}-alias@%{domainName}"
+}
+`, testUserVals)
+}
@@ -0,0 +1,408 @@
+package googleworkspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func diffSuppressUserCustomSchemaValues(_, _, _ string, d *schema.ResourceData) bool {
+	old, new := d.GetChange("schema_values")
+	schemaName := d.Get("schema_name").(string)
+
+	oldMap := transformCustomSchemasTo2DMap([]interface{}{
+		map[string]interface{}{"schema_name": schemaName, "schema_values": old},
+	})
+	newMap := transformCustomSchemasTo2DMap([]interface{}{
+		map[string]interface{}{"schema_name": schemaName, "schema_values": new},
+	})
+
+	return reflect.DeepEqual(oldMap, newMap)
+}
+
+func resourceUserCustomSchemaValues() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "User Custom Schema Values resource manages the values of a single custom schema for a " +
+			"Google Workspace User, without managing the rest of the user. Only the fields of `schema_name` " +
+			"are written, and fields of the schema that are not configured are cleared. Use `ignored_custom_schemas` " +
+			"on `googleworkspace_user` to stop it from managing the same schema. User Custom Schema Values resides " +
+			"under the `https://www.googleapis.com/auth/admin.directory.user` client scope.",
+
+		CreateContext: resourceUserCustomSchemaValuesCreate,
+		ReadContext:   resourceUserCustomSchemaValuesRead,
+		UpdateContext: resourceUserCustomSchemaValuesUpdate,
+		DeleteContext: resourceUserCustomSchemaValuesDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceUserCustomSchemaValuesImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Description: "Identifies the user in the API request. The value can be the user's primary email " +
+					"address, alias email address, or unique user ID.",
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"schema_name": {
+				Description: "The name of the custom schema.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"schema_values": {
+				Description:      "JSON encoded map that represents key/value pairs that correspond to the given schema.",
+				Type:             schema.TypeMap,
+				Required:         true,
+				DiffSuppressFunc: diffSuppressUserCustomSchemaValues,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(
+						validation.StringIsJSON,
+					),
+				},
+			},
+			"etag": {
+				Description: "ETag of the user.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceUserCustomSchemaValuesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	schemaName := d.Get("schema_name").(string)
+	log.Printf("[DEBUG] Creating User Custom Schema Values %q for User %q", schemaName, userId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	// fields the user already has a value for that are not configured are cleared
+	fields, err := userCustomSchemaFields(usersService, userId, schemaName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	schemaValues := d.Get("schema_values").(map[string]interface{})
+	var removed []string
+	for _, k := range fields {
+		if _, ok := schemaValues[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+
+	diags = patchUserCustomSchemaValues(ctx, d, meta, removed, d.Timeout(schema.TimeoutCreate))
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("users/%s/schemas/%s", userId, schemaName))
+
+	log.Printf("[DEBUG] Finished creating User Custom Schema Values %q for User %q", schemaName, userId)
+
+	return resourceUserCustomSchemaValuesRead(ctx, d, meta)
+}
+
+func resourceUserCustomSchemaValuesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	schemaName := d.Get("schema_name").(string)
+	log.Printf("[DEBUG] Getting User Custom Schema Values %q for User %q", schemaName, userId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	user, err := usersService.Get(userId).Projection("custom").CustomFieldMask(schemaName).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	schemaValues := map[string]interface{}{}
+	if sv, ok := user.CustomSchemas[schemaName]; ok {
+		customSchemas, diags := flattenCustomSchemas(map[string]googleapi.RawMessage{schemaName: sv}, client)
+		if diags.HasError() {
+			return diags
+		}
+
+		if len(customSchemas) > 0 {
+			schemaValues = customSchemas[0]["schema_values"].(map[string]interface{})
+		}
+	}
+
+	d.Set("schema_values", schemaValues)
+	d.Set("etag", user.Etag)
+
+	d.SetId(fmt.Sprintf("users/%s/schemas/%s", userId, schemaName))
+	log.Printf("[DEBUG] Finished getting User Custom Schema Values %q for User %q", schemaName, userId)
+
+	return diags
+}
+
+func resourceUserCustomSchemaValuesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	userId := d.Get("user_id").(string)
+	schemaName := d.Get("schema_name").(string)
+	log.Printf("[DEBUG] Updating User Custom Schema Values %q for User %q", schemaName, userId)
+
+	// fields that are no longer configured are cleared
+	old, new := d.GetChange("schema_values")
+	var removed []string
+	for k := range old.(map[string]interface{}) {
+		if _, ok := new.(map[string]interface{})[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+
+	diags := patchUserCustomSchemaValues(ctx, d, meta, removed, d.Timeout(schema.TimeoutUpdate))
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished updating User Custom Schema Values %q for User %q", schemaName, userId)
+
+	return resourceUserCustomSchemaValuesRead(ctx, d, meta)
+}
+
+func resourceUserCustomSchemaValuesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	schemaName := d.Get("schema_name").(string)
+	log.Printf("[DEBUG] Deleting User Custom Schema Values %q for User %q", schemaName, userId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	var fields []string
+	for k := range d.Get("schema_values").(map[string]interface{}) {
+		fields = append(fields, k)
+	}
+
+	schemaValues, err := clearCustomSchemaFields(googleapi.RawMessage("{}"), fields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	userObj := directory.User{
+		CustomSchemas: map[string]googleapi.RawMessage{schemaName: schemaValues},
+	}
+
+	_, err = usersService.Patch(userId, &userObj).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	log.Printf("[DEBUG] Finished deleting User Custom Schema Values %q for User %q", schemaName, userId)
+
+	return diags
+}
+
+func resourceUserCustomSchemaValuesImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+
+	// id is of format "users/<user_id>/schemas/<schema_name>"
+	if len(parts) != 4 || parts[0] != "users" || parts[2] != "schemas" {
+		return nil, fmt.Errorf("user custom schema values id (%s) is not of the correct format (users/<user_id>/schemas/<schema_name>)", d.Id())
+	}
+
+	d.Set("user_id", parts[1])
+	d.Set("schema_name", parts[3])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// patchUserCustomSchemaValues validates and patches the configured schema values onto the user,
+// clearing the removed fields, and waits for the user to be consistent.
+func patchUserCustomSchemaValues(ctx context.Context, d *schema.ResourceData, meta interface{}, removed []string, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	schemaName := d.Get("schema_name").(string)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	schemasService, diags := GetSchemasService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	customSchemas := []interface{}{
+		map[string]interface{}{
+			"schema_name":   schemaName,
+			"schema_values": d.Get("schema_values").(map[string]interface{}),
+		},
+	}
+
+	diags = validateCustomSchemaValues(customSchemas, func(schemaName string) (*directory.Schema, error) {
+		return schemasService.Get(client.Customer, schemaName).Do()
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	customSchemaValues, diags := expandCustomSchemaValues(customSchemas)
+	if diags.HasError() {
+		return diags
+	}
+
+	schemaValues, err := clearCustomSchemaFields(customSchemaValues[schemaName], removed)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// only the configured schema is sent, so the user's other schemas are left untouched
+	userObj := directory.User{
+		CustomSchemas: map[string]googleapi.RawMessage{schemaName: schemaValues},
+	}
+
+	user, err := usersService.Patch(userId, &userObj).Do()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// PATCH will respond with the updated User, however, it is eventually consistent
+	// once we get a consistent etag, we can feel confident that our values are also consistent
+	cc := consistencyCheck{
+		resourceType: "user custom schema values",
+		timeout:      timeout,
+	}
+	err = retryTimeDuration(ctx, timeout, func() error {
+		var retryErr error
+
+		if cc.reachedConsistency(1) {
+			return nil
+		}
+
+		newUser, retryErr := usersService.Get(user.Id).IfNoneMatch(cc.lastEtag).Do()
+		if googleapi.IsNotModified(retryErr) {
+			cc.currConsistent += 1
+		} else if retryErr != nil {
+			return fmt.Errorf("unexpected error during retries of %s: %s", cc.resourceType, retryErr)
+		} else {
+			cc.handleNewEtag(newUser.Etag)
+		}
+
+		return fmt.Errorf("timed out while waiting for %s to be updated", cc.resourceType)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// userCustomSchemaFields returns the names of the fields of the custom schema that the user has a value for.
+func userCustomSchemaFields(usersService *directory.UsersService, userId, schemaName string) ([]string, error) {
+	user, err := usersService.Get(userId).Projection("custom").CustomFieldMask(schemaName).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	sv, ok := user.CustomSchemas[schemaName]
+	if !ok {
+		return nil, nil
+	}
+
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(sv, &values); err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	for k := range values {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+
+	return fields, nil
+}
+
+// clearCustomSchemaFields sets the given fields to null in the schema values, which clears
+// them when patching the user.
+func clearCustomSchemaFields(schemaValues googleapi.RawMessage, fields []string) (googleapi.RawMessage, error) {
+	if len(fields) == 0 {
+		return schemaValues, nil
+	}
+
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(schemaValues, &values); err != nil {
+		return nil, err
+	}
+
+	for _, field := range fields {
+		if _, ok := values[field]; !ok {
+			values[field] = nil
+		}
+	}
+
+	return json.Marshal(values)
+}
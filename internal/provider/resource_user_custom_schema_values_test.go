@@ -0,0 +1,167 @@
+package googleworkspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"google.golang.org/api/googleapi"
+)
+
+func TestClearCustomSchemaFields(t *testing.T) {
+	schemaValues, err := clearCustomSchemaFields(googleapi.RawMessage(`{"EmployeeNumber":"42"}`), []string{"Title", "EmployeeNumber"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var actual map[string]interface{}
+	if err := json.Unmarshal(schemaValues, &actual); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"EmployeeNumber": "42",
+		"Title":          nil,
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %+v, got %+v", expected, actual)
+	}
+}
+
+func TestUserCustomSchemaFields(t *testing.T) {
+	client, requests := newFakeApiServer(t, "/admin/directory/v1", func(w http.ResponseWriter, r *http.Request, path string) {
+		switch path {
+		case "/users/alice@example.com":
+			fmt.Fprint(w, `{"customSchemas": {"employee": {"Title": "Engineer", "EmployeeNumber": "42"}}}`)
+		case "/users/bob@example.com":
+			fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "Resource Not Found"}}`)
+		}
+	})
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	for userId, expected := range map[string][]string{
+		"alice@example.com": {"EmployeeNumber", "Title"},
+		"bob@example.com":   nil,
+	} {
+		actual, err := userCustomSchemaFields(usersService, userId, "employee")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: expected %v, got %v", userId, expected, actual)
+		}
+	}
+
+	if _, err := userCustomSchemaFields(usersService, "carol@example.com", "employee"); !isNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	for _, request := range requests() {
+		if !strings.HasPrefix(request, "GET ") {
+			t.Errorf("expected only reads, got %q", request)
+		}
+	}
+}
+
+func TestAccResourceUserCustomSchemaValues_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testUserVals := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"password":   acctest.RandString(10),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceUserCustomSchemaValues(testUserVals, `"bar" = jsonencode("Bar")`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_user_custom_schema_values.bar", "schema_values.bar", `"Bar"`),
+					resource.TestCheckResourceAttr("googleworkspace_user.my-new-user", "custom_schemas.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_user_custom_schema_values.bar",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccResourceUserCustomSchemaValues(testUserVals, `"baz" = jsonencode(["a", "b"])`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_user_custom_schema_values.bar", "schema_values.%", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceUserCustomSchemaValues(testUserVals map[string]interface{}, schemaValues string) string {
+	testUserVals["schemaValues"] = schemaValues
+
+	return Nprintf(`
+resource "googleworkspace_schema" "bar-schema" {
+  schema_name = "%{userEmail}-bar-schema"
+
+  fields {
+    field_name = "bar"
+    field_type = "STRING"
+  }
+
+  fields {
+    field_name   = "baz"
+    field_type   = "STRING"
+    multi_valued = true
+  }
+}
+
+resource "googleworkspace_user" "my-new-user" {
+  primary_email = "%{userEmail}@%{domainName}"
+  password      = "%{password}"
+
+  name {
+    family_name = "Scott"
+    given_name  = "Michael"
+  }
+
+  ignored_custom_schemas = [googleworkspace_schema.bar-schema.schema_name]
+}
+
+resource "googleworkspace_user_custom_schema_values" "bar" {
+  user_id     = googleworkspace_user.my-new-user.id
+  schema_name = googleworkspace_schema.bar-schema.schema_name
+
+  schema_values = {
+    %{schemaValues}
+  }
+}
+`, testUserVals)
+}
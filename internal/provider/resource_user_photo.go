@@ -0,0 +1,462 @@
+package googleworkspace
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"log"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
+)
+
+const (
+	// userPhotoMaxBytes and userPhotoMaxDimension are the limits above which photos are resized
+	// and re-encoded before uploading. The API downsizes every photo to 96x96 pixels regardless.
+	userPhotoMaxBytes     = 2 << 20
+	userPhotoMaxDimension = 1024
+)
+
+func resourceUserPhoto() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "User Photo resource uploads a local JPEG or PNG file as the profile photo of a Google Workspace " +
+			"User. Images larger than 2 MB or 1024 pixels in either dimension are resized and re-encoded before " +
+			"uploading. The photo is deleted when the resource is destroyed. User Photo resides under the " +
+			"`https://www.googleapis.com/auth/admin.directory.user` client scope.",
+
+		CreateContext: resourceUserPhotoCreate,
+		ReadContext:   resourceUserPhotoRead,
+		UpdateContext: resourceUserPhotoUpdate,
+		DeleteContext: resourceUserPhotoDelete,
+
+		CustomizeDiff: resourceUserPhotoCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Description: "Identifies the user in the API request. The value can be the user's primary email " +
+					"address, alias email address, or unique user ID.",
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"file_path": {
+				Description: "The local path of the JPEG or PNG file to upload. Changes to the content of the file " +
+					"will trigger a new upload.",
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"file_hash": {
+				Description: "SHA256 hash of the file content. Automatically computed from file_path. " +
+					"Used to detect changes to the file content.",
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"etag": {
+				Description: "ETag of the photo. A photo changed outside of Terraform is detected through its etag " +
+					"and uploaded again.",
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"mime_type": {
+				Description: "The MIME type of the photo.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"height": {
+				Description: "Height of the photo in pixels.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"width": {
+				Description: "Width of the photo in pixels.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceUserPhotoCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	computed := []string{"file_hash", "etag", "mime_type", "height", "width"}
+
+	if !d.NewValueKnown("file_path") {
+		for _, k := range computed {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	fileHash, err := userPhotoFileHash(d.Get("file_path").(string))
+	if err != nil {
+		return err
+	}
+
+	if fileHash == d.Get("file_hash").(string) {
+		return nil
+	}
+
+	if err := d.SetNew("file_hash", fileHash); err != nil {
+		return err
+	}
+
+	for _, k := range computed[1:] {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceUserPhotoCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	userId := d.Get("user_id").(string)
+	log.Printf("[DEBUG] Creating User Photo for User %q", userId)
+
+	photo, diags := uploadUserPhoto(ctx, d, meta, d.Timeout(schema.TimeoutCreate))
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(photo.Id)
+
+	log.Printf("[DEBUG] Finished creating User Photo for User %q", userId)
+
+	return resourceUserPhotoRead(ctx, d, meta)
+}
+
+func resourceUserPhotoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	log.Printf("[DEBUG] Getting User Photo for User %q", userId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	photosService, diags := GetUserPhotosService(usersService)
+	if diags.HasError() {
+		return diags
+	}
+
+	photo, err := photosService.Get(userId).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	// a different etag means the photo was changed outside of Terraform, clearing the hash
+	// makes the next plan upload the file again
+	if etag := d.Get("etag").(string); etag != "" && etag != photo.Etag {
+		log.Printf("[DEBUG] User Photo for User %q changed outside of Terraform", userId)
+		d.Set("file_hash", "")
+	}
+
+	d.Set("etag", photo.Etag)
+	d.Set("mime_type", photo.MimeType)
+	d.Set("height", photo.Height)
+	d.Set("width", photo.Width)
+
+	log.Printf("[DEBUG] Finished getting User Photo for User %q", userId)
+
+	return diags
+}
+
+func resourceUserPhotoUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	userId := d.Get("user_id").(string)
+	log.Printf("[DEBUG] Updating User Photo for User %q", userId)
+
+	if d.HasChange("file_hash") {
+		_, diags := uploadUserPhoto(ctx, d, meta, d.Timeout(schema.TimeoutUpdate))
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	log.Printf("[DEBUG] Finished updating User Photo for User %q", userId)
+
+	return resourceUserPhotoRead(ctx, d, meta)
+}
+
+func resourceUserPhotoDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	log.Printf("[DEBUG] Deleting User Photo for User %q", userId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	photosService, diags := GetUserPhotosService(usersService)
+	if diags.HasError() {
+		return diags
+	}
+
+	err := photosService.Delete(userId).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	log.Printf("[DEBUG] Finished deleting User Photo for User %q", userId)
+
+	return diags
+}
+
+// uploadUserPhoto uploads the configured file as the user's photo, and waits for the photo to be consistent.
+func uploadUserPhoto(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) (*directory.UserPhoto, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	filePath := d.Get("file_path").(string)
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, diag.Errorf("failed to read file %q: %v", filePath, err)
+	}
+
+	hash := sha256.Sum256(data)
+	fileHash := hex.EncodeToString(hash[:])
+
+	photoData, mimeType, width, height, err := prepareUserPhoto(data)
+	if err != nil {
+		return nil, diag.Errorf("failed to prepare photo %q: %v", filePath, err)
+	}
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	photosService, diags := GetUserPhotosService(usersService)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	log.Printf("[DEBUG] Uploading file %q (size: %d bytes, hash: %s, mime type: %s, %dx%d) as photo of User %q",
+		filePath, len(photoData), fileHash, mimeType, width, height, userId)
+
+	photoObj := directory.UserPhoto{
+		PhotoData: base64.URLEncoding.EncodeToString(photoData),
+		MimeType:  mimeType,
+		Width:     int64(width),
+		Height:    int64(height),
+	}
+
+	photo, err := photosService.Update(userId, &photoObj).Do()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	// UPDATE will respond with the Photo that will be uploaded, however, it is eventually consistent
+	// once we get a consistent etag, we can feel confident that our Photo is also consistent
+	cc := consistencyCheck{
+		resourceType: "user photo",
+		timeout:      timeout,
+	}
+	err = retryTimeDuration(ctx, timeout, func() error {
+		var retryErr error
+
+		if cc.reachedConsistency(1) {
+			return nil
+		}
+
+		newPhoto, retryErr := photosService.Get(userId).IfNoneMatch(cc.lastEtag).Do()
+		if googleapi.IsNotModified(retryErr) {
+			cc.currConsistent += 1
+		} else if isNotFound(retryErr) {
+			// photo was not found yet therefore setting currConsistent back to null value
+			cc.currConsistent = 0
+		} else if retryErr != nil {
+			return fmt.Errorf("unexpected error during retries of %s: %s", cc.resourceType, retryErr)
+		} else {
+			cc.handleNewEtag(newPhoto.Etag)
+		}
+
+		return fmt.Errorf("timed out while waiting for %s to be updated", cc.resourceType)
+	})
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	// the etag is set here so that the following read does not report the upload as drift
+	d.Set("file_hash", fileHash)
+	d.Set("etag", cc.lastEtag)
+
+	return photo, diags
+}
+
+func userPhotoFileHash(filePath string) (string, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %q: %v", filePath, err)
+	}
+
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// prepareUserPhoto validates that the data is a JPEG or PNG image, and resizes and re-encodes
+// images exceeding userPhotoMaxBytes or userPhotoMaxDimension. It returns the data to upload,
+// along with its MIME type and dimensions.
+func prepareUserPhoto(data []byte) ([]byte, string, int, int, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", 0, 0, fmt.Errorf("unable to decode image: %v", err)
+	}
+
+	if format != "jpeg" && format != "png" {
+		return nil, "", 0, 0, fmt.Errorf("unsupported image format %q, expected JPEG or PNG", format)
+	}
+
+	mimeType := "JPEG"
+	if format == "png" {
+		mimeType = "PNG"
+	}
+
+	if len(data) <= userPhotoMaxBytes && config.Width <= userPhotoMaxDimension && config.Height <= userPhotoMaxDimension {
+		return data, mimeType, config.Width, config.Height, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", 0, 0, fmt.Errorf("unable to decode image: %v", err)
+	}
+
+	img = resizeImage(img, userPhotoMaxDimension)
+	bounds := img.Bounds()
+
+	if format == "png" {
+		var buf bytes.Buffer
+		encoder := png.Encoder{CompressionLevel: png.BestCompression}
+		if err := encoder.Encode(&buf, img); err != nil {
+			return nil, "", 0, 0, err
+		}
+
+		if buf.Len() <= userPhotoMaxBytes {
+			return buf.Bytes(), "PNG", bounds.Dx(), bounds.Dy(), nil
+		}
+
+		// photos that are still too large as PNG are converted to JPEG, flattening
+		// any transparency onto a white background
+		flattened := image.NewRGBA(bounds)
+		draw.Draw(flattened, bounds, image.NewUniform(color.White), image.Point{}, draw.Src)
+		draw.Draw(flattened, bounds, img, bounds.Min, draw.Over)
+		img = flattened
+	}
+
+	for quality := 90; quality >= 30; quality -= 10 {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, "", 0, 0, err
+		}
+
+		if buf.Len() <= userPhotoMaxBytes {
+			return buf.Bytes(), "JPEG", bounds.Dx(), bounds.Dy(), nil
+		}
+	}
+
+	return nil, "", 0, 0, fmt.Errorf("unable to re-encode image under %d bytes", userPhotoMaxBytes)
+}
+
+// resizeImage scales the image down, keeping its aspect ratio, so that neither dimension exceeds
+// maxDimension. Each pixel of the result is the average of the source pixels it covers.
+func resizeImage(img image.Image, maxDimension int) image.Image {
+	bounds := img.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+
+	if srcWidth <= maxDimension && srcHeight <= maxDimension {
+		return img
+	}
+
+	dstWidth, dstHeight := maxDimension, maxDimension
+	if srcWidth > srcHeight {
+		dstHeight = max(1, srcHeight*maxDimension/srcWidth)
+	} else {
+		dstWidth = max(1, srcWidth*maxDimension/srcHeight)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0 := bounds.Min.Y + y*srcHeight/dstHeight
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcHeight/dstHeight)
+
+		for x := 0; x < dstWidth; x++ {
+			x0 := bounds.Min.X + x*srcWidth/dstWidth
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcWidth/dstWidth)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					sr, sg, sb, sa := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(sr), g+uint64(sg), b+uint64(sb), a+uint64(sa)
+					n++
+				}
+			}
+
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,166 @@
+package googleworkspace
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testUserPhotoImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: uint8(x ^ y), A: 255})
+		}
+	}
+
+	return img
+}
+
+func TestPrepareUserPhoto(t *testing.T) {
+	var small bytes.Buffer
+	if err := png.Encode(&small, testUserPhotoImage(64, 32)); err != nil {
+		t.Fatal(err)
+	}
+
+	data, mimeType, width, height, err := prepareUserPhoto(small.Bytes())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(data, small.Bytes()) || mimeType != "PNG" || width != 64 || height != 32 {
+		t.Errorf("expected a small photo to be uploaded as is, got %s %dx%d", mimeType, width, height)
+	}
+
+	var large bytes.Buffer
+	if err := jpeg.Encode(&large, testUserPhotoImage(2048, 1536), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	data, mimeType, width, height, err = prepareUserPhoto(large.Bytes())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if mimeType != "JPEG" || width != 1024 || height != 768 {
+		t.Errorf("expected a large photo to be resized to a 1024x768 JPEG, got %s %dx%d", mimeType, width, height)
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unable to decode resized photo: %s", err)
+	}
+	if format != "jpeg" || config.Width != 1024 || config.Height != 768 || len(data) > userPhotoMaxBytes {
+		t.Errorf("unexpected resized photo: %s %dx%d, %d bytes", format, config.Width, config.Height, len(data))
+	}
+
+	var unsupported bytes.Buffer
+	if err := gif.Encode(&unsupported, testUserPhotoImage(8, 8), nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, _, err := prepareUserPhoto(unsupported.Bytes()); err == nil {
+		t.Errorf("expected an error for a GIF photo")
+	}
+}
+
+func TestResizeImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+		img.Set(x, 1, color.RGBA{B: 255, A: 255})
+	}
+
+	resized := resizeImage(img, 2)
+	if bounds := resized.Bounds(); bounds.Dx() != 2 || bounds.Dy() != 1 {
+		t.Fatalf("expected a 2x1 image, got %dx%d", bounds.Dx(), bounds.Dy())
+	}
+
+	// each pixel averages a red and a blue pixel
+	r, g, b, a := resized.At(0, 0).RGBA()
+	if r>>8 != 127 || g != 0 || b>>8 != 127 || a>>8 != 255 {
+		t.Errorf("unexpected averaged color %d,%d,%d,%d", r>>8, g>>8, b>>8, a>>8)
+	}
+
+	if resizeImage(img, 8) != img {
+		t.Errorf("expected an image within the limit to be returned as is")
+	}
+}
+
+func TestAccResourceUserPhoto_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	tmpDir := t.TempDir()
+	photoPath := filepath.Join(tmpDir, "photo.png")
+
+	writePhoto := func(width int) {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, testUserPhotoImage(width, 96)); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(photoPath, buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writePhoto(96)
+
+	testUserVals := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"password":   acctest.RandString(10),
+		"photoPath":  photoPath,
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceUserPhoto_basic(testUserVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("googleworkspace_user_photo.photo", "file_hash"),
+					resource.TestCheckResourceAttrSet("googleworkspace_user_photo.photo", "etag"),
+				),
+			},
+			{
+				PreConfig: func() { writePhoto(128) },
+				Config:    testAccResourceUserPhoto_basic(testUserVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("googleworkspace_user_photo.photo", "etag"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceUserPhoto_basic(testUserVals map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_user" "user" {
+  primary_email = "%{userEmail}@%{domainName}"
+  password      = "%{password}"
+
+  name {
+    family_name = "Scott"
+    given_name  = "Michael"
+  }
+}
+
+resource "googleworkspace_user_photo" "photo" {
+  user_id   = googleworkspace_user.user.id
+  file_path = "%{photoPath}"
+}
+`, testUserVals)
+}
@@ -0,0 +1,268 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func resourceUserSecurityAction() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "User Security Action resource runs one-off security actions against a Google Workspace User, " +
+			"such as signing the user out of all sessions, revoking OAuth tokens and application-specific passwords, " +
+			"and rotating backup verification codes. The actions run when the resource is created, and again " +
+			"whenever `triggers` or any other argument changes. Destroying the resource only removes it from the " +
+			"state. User Security Action resides under the `https://www.googleapis.com/auth/admin.directory.user` " +
+			"and `https://www.googleapis.com/auth/admin.directory.user.security` client scopes.",
+
+		CreateContext: resourceUserSecurityActionCreate,
+		ReadContext:   resourceUserSecurityActionRead,
+		DeleteContext: resourceUserSecurityActionDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Description: "Identifies the user in the API request. The value can be the user's primary email " +
+					"address, alias email address, or unique user ID.",
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"triggers": {
+				Description: "Arbitrary map of values that, when changed, will run the actions again.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"sign_out": {
+				Description: "Signs the user out of all web and device sessions and resets " +
+					"their sign-in cookies.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"revoke_tokens": {
+				Description: "Revokes the OAuth tokens the user has issued to third-party " +
+					"applications, either all of them or only those of `token_client_ids`.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"token_client_ids": {
+				Description: "The client IDs of the applications whose tokens are revoked. If empty, all of the " +
+					"user's tokens are revoked.",
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"revoke_tokens"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"revoke_asps": {
+				Description: "Revokes all of the user's application-specific passwords.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"rotate_verification_codes": {
+				Description: "Invalidates the user's backup verification codes and generates " +
+					"new ones. The new codes are not stored in the state.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"actions": {
+				Description: "The actions that were performed, in order, as the API method and, for revocations, " +
+					"the client ID or code ID, e.g. `tokens.delete:<client_id>`.",
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"performed_at": {
+				Description: "The time when the actions were performed.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceUserSecurityActionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	log.Printf("[DEBUG] Creating User Security Action for User %q", userId)
+
+	if !d.Get("sign_out").(bool) && !d.Get("revoke_tokens").(bool) && !d.Get("revoke_asps").(bool) &&
+		!d.Get("rotate_verification_codes").(bool) {
+		return diag.Errorf("at least one of sign_out, revoke_tokens, revoke_asps or rotate_verification_codes must be true")
+	}
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	actions, err := performUserSecurityActions(directoryService, d)
+	if err != nil {
+		if len(actions) > 0 {
+			return diag.Errorf("%s (actions already performed: %v)", err, actions)
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resource.UniqueId())
+	d.Set("actions", actions)
+	d.Set("performed_at", time.Now().Format(time.RFC3339))
+
+	log.Printf("[DEBUG] Finished creating User Security Action %q for User %q: %v", d.Id(), userId, actions)
+
+	return resourceUserSecurityActionRead(ctx, d, meta)
+}
+
+func resourceUserSecurityActionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The actions can't be read back from the API, the state is the record of what was performed
+	log.Printf("[DEBUG] Getting User Security Action %q", d.Id())
+
+	return diags
+}
+
+func resourceUserSecurityActionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The actions can't be undone, we just remove from Terraform state
+	log.Printf("[DEBUG] Removing User Security Action %q from state", d.Id())
+
+	d.SetId("")
+
+	return diags
+}
+
+// performUserSecurityActions runs the configured actions, revoking credentials before signing the user
+// out so that no session is restored from them. It returns the actions performed, including when an
+// action fails part way through.
+func performUserSecurityActions(directoryService *directory.Service, d *schema.ResourceData) ([]string, error) {
+	userId := d.Get("user_id").(string)
+	actions := []string{}
+
+	if d.Get("revoke_tokens").(bool) {
+		tokensService, diags := GetTokensService(directoryService)
+		if diags.HasError() {
+			return actions, fmt.Errorf("%s", diags[0].Summary)
+		}
+
+		clientIds := listOfInterfacestoStrings(d.Get("token_client_ids").(*schema.Set).List())
+		if len(clientIds) == 0 {
+			tokens, err := tokensService.List(userId).Do()
+			if err != nil {
+				return actions, err
+			}
+
+			for _, token := range tokens.Items {
+				clientIds = append(clientIds, token.ClientId)
+			}
+		}
+
+		for _, clientId := range clientIds {
+			err := tokensService.Delete(userId, clientId).Do()
+			if isNotFound(err) {
+				log.Printf("[DEBUG] User %q has no token for client %q", userId, clientId)
+				continue
+			}
+			if err != nil {
+				return actions, err
+			}
+
+			actions = append(actions, fmt.Sprintf("tokens.delete:%s", clientId))
+		}
+	}
+
+	if d.Get("revoke_asps").(bool) {
+		aspsService, diags := GetAspsService(directoryService)
+		if diags.HasError() {
+			return actions, fmt.Errorf("%s", diags[0].Summary)
+		}
+
+		asps, err := aspsService.List(userId).Do()
+		if err != nil {
+			return actions, err
+		}
+
+		for _, asp := range asps.Items {
+			err := aspsService.Delete(userId, asp.CodeId).Do()
+			if isNotFound(err) {
+				log.Printf("[DEBUG] ASP %d of User %q is already gone", asp.CodeId, userId)
+				continue
+			}
+			if err != nil {
+				return actions, err
+			}
+
+			actions = append(actions, fmt.Sprintf("asps.delete:%d", asp.CodeId))
+		}
+	}
+
+	if d.Get("rotate_verification_codes").(bool) {
+		verificationCodesService, diags := GetVerificationCodesService(directoryService)
+		if diags.HasError() {
+			return actions, fmt.Errorf("%s", diags[0].Summary)
+		}
+
+		if err := verificationCodesService.Invalidate(userId).Do(); err != nil {
+			return actions, err
+		}
+		actions = append(actions, "verificationCodes.invalidate")
+
+		if err := verificationCodesService.Generate(userId).Do(); err != nil {
+			return actions, err
+		}
+		actions = append(actions, "verificationCodes.generate")
+	}
+
+	if d.Get("sign_out").(bool) {
+		usersService, diags := GetUsersService(directoryService)
+		if diags.HasError() {
+			return actions, fmt.Errorf("%s", diags[0].Summary)
+		}
+
+		if err := usersService.SignOut(userId).Do(); err != nil {
+			return actions, err
+		}
+		actions = append(actions, "users.signOut")
+	}
+
+	return actions, nil
+}
@@ -0,0 +1,149 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// newFakeDirectoryServer returns a client for a fake Directory API that serves the tokens and ASPs
+// of a single user, and a func returning the requests received.
+func newFakeDirectoryServer(t *testing.T) (*apiClient, func() []string) {
+	return newFakeApiServer(t, "/admin/directory/v1", func(w http.ResponseWriter, r *http.Request, path string) {
+		switch {
+		case r.Method == http.MethodGet && path == "/users/jane@example.com/tokens":
+			fmt.Fprint(w, `{"items": [{"clientId": "client-a"}, {"clientId": "client-b"}]}`)
+		case r.Method == http.MethodGet && path == "/users/jane@example.com/asps":
+			fmt.Fprint(w, `{"items": [{"codeId": 42}, {"codeId": 43}]}`)
+		case r.Method == http.MethodDelete && path == "/users/jane@example.com/tokens/client-gone":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "Resource Not Found: client-gone"}}`)
+		case r.Method == http.MethodDelete && path == "/users/jane@example.com/asps/43":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "Resource Not Found: 43"}}`)
+		case strings.HasPrefix(path, "/users/jane@example.com/"):
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "Resource Not Found: userKey"}}`)
+		}
+	})
+}
+
+func TestResourceUserSecurityActionCreate(t *testing.T) {
+	client, requests := newFakeDirectoryServer(t)
+
+	d := schema.TestResourceDataRaw(t, resourceUserSecurityAction().Schema, map[string]interface{}{
+		"user_id":                   "jane@example.com",
+		"sign_out":                  true,
+		"revoke_tokens":             true,
+		"revoke_asps":               true,
+		"rotate_verification_codes": true,
+	})
+
+	diags := resourceUserSecurityActionCreate(context.Background(), d, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expectedRequests := []string{
+		"GET /users/jane@example.com/tokens",
+		"DELETE /users/jane@example.com/tokens/client-a",
+		"DELETE /users/jane@example.com/tokens/client-b",
+		"GET /users/jane@example.com/asps",
+		"DELETE /users/jane@example.com/asps/42",
+		"DELETE /users/jane@example.com/asps/43",
+		"POST /users/jane@example.com/verificationCodes/invalidate",
+		"POST /users/jane@example.com/verificationCodes/generate",
+		"POST /users/jane@example.com/signOut",
+	}
+	if actual := requests(); !reflect.DeepEqual(actual, expectedRequests) {
+		t.Errorf("expected requests %v, got %v", expectedRequests, actual)
+	}
+
+	// the ASP that is already gone is not recorded
+	expectedActions := []interface{}{
+		"tokens.delete:client-a",
+		"tokens.delete:client-b",
+		"asps.delete:42",
+		"verificationCodes.invalidate",
+		"verificationCodes.generate",
+		"users.signOut",
+	}
+	if actual := d.Get("actions").([]interface{}); !reflect.DeepEqual(actual, expectedActions) {
+		t.Errorf("expected actions %v, got %v", expectedActions, actual)
+	}
+
+	if d.Id() == "" || d.Get("performed_at").(string) == "" {
+		t.Errorf("expected the id and performed_at to be set")
+	}
+}
+
+func TestResourceUserSecurityActionCreate_tokenClientIds(t *testing.T) {
+	client, requests := newFakeDirectoryServer(t)
+
+	d := schema.TestResourceDataRaw(t, resourceUserSecurityAction().Schema, map[string]interface{}{
+		"user_id":          "jane@example.com",
+		"revoke_tokens":    true,
+		"token_client_ids": []interface{}{"client-gone"},
+	})
+
+	diags := resourceUserSecurityActionCreate(context.Background(), d, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// only the configured client is revoked, and a token that is already gone is not recorded
+	expectedRequests := []string{"DELETE /users/jane@example.com/tokens/client-gone"}
+	if actual := requests(); !reflect.DeepEqual(actual, expectedRequests) {
+		t.Errorf("expected requests %v, got %v", expectedRequests, actual)
+	}
+
+	if actual := d.Get("actions").([]interface{}); len(actual) != 0 {
+		t.Errorf("expected no actions, got %v", actual)
+	}
+}
+
+func TestResourceUserSecurityActionCreate_failure(t *testing.T) {
+	client, requests := newFakeDirectoryServer(t)
+
+	d := schema.TestResourceDataRaw(t, resourceUserSecurityAction().Schema, map[string]interface{}{
+		"user_id":  "unknown@example.com",
+		"sign_out": true,
+	})
+
+	diags := resourceUserSecurityActionCreate(context.Background(), d, client)
+	if !diags.HasError() {
+		t.Fatalf("expected an error for an unknown user")
+	}
+
+	if d.Id() != "" {
+		t.Errorf("expected the resource to not be created")
+	}
+
+	if actual := requests(); len(actual) != 1 {
+		t.Errorf("expected a single request, got %v", actual)
+	}
+}
+
+func TestResourceUserSecurityActionCreate_noActions(t *testing.T) {
+	client, requests := newFakeDirectoryServer(t)
+
+	d := schema.TestResourceDataRaw(t, resourceUserSecurityAction().Schema, map[string]interface{}{
+		"user_id": "jane@example.com",
+	})
+
+	diags := resourceUserSecurityActionCreate(context.Background(), d, client)
+	if !diags.HasError() {
+		t.Fatalf("expected an error when no action is enabled")
+	}
+
+	if actual := requests(); len(actual) != 0 {
+		t.Errorf("expected no requests, got %v", actual)
+	}
+}
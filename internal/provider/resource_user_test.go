@@ -3,12 +3,18 @@ package googleworkspace
 import (
 	"fmt"
 	"os"
+	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	datatransfer "google.golang.org/api/admin/datatransfer/v1"
+	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
 )
 
 func TestAccResourceUser_basic(t *testing.T) {
@@ -307,6 +313,331 @@ func TestAccResourceUser_customSchemasMultiple(t *testing.T) {
 	})
 }
 
+func TestAccResourceUser_restoreIfDeleted(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testUserVals := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"password":   acctest.RandString(10),
+	}
+
+	var userId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceUser_basic(testUserVals),
+				Check: func(s *terraform.State) error {
+					userId = s.RootModule().Resources["googleworkspace_user.my-new-user"].Primary.ID
+					return nil
+				},
+			},
+			{
+				Config:  testAccResourceUser_basic(testUserVals),
+				Destroy: true,
+			},
+			{
+				Config: testAccResourceUser_restoreIfDeleted(testUserVals),
+				Check: func(s *terraform.State) error {
+					restoredId := s.RootModule().Resources["googleworkspace_user.my-new-user"].Primary.ID
+					if restoredId != userId {
+						return fmt.Errorf("expected deleted user %s to be restored, got %s", userId, restoredId)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceUser_writeOnlyPassword(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testUserVals := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"password":   acctest.RandString(10),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceUser_writeOnlyPassword(testUserVals),
+				Check: func(s *terraform.State) error {
+					stored := s.RootModule().Resources["googleworkspace_user.my-new-user"].Primary.Attributes["password"]
+					if !passwordMatchesStateHash(stored, testUserVals["password"].(string)) {
+						return fmt.Errorf("expected a salted hash of the password in state, got %q", stored)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceUser_unmanagedAttributes(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testUserVals := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"password":   acctest.RandString(10),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceUser_unmanagedAttributes(testUserVals, "Sales"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_user.my-new-user", "owned_attributes.#",
+						fmt.Sprintf("%d", len(userOwnableAttributes)-2)),
+					resource.TestCheckResourceAttr("googleworkspace_user.my-new-user", "organizations.0.department", "Sales"),
+				),
+			},
+			{
+				// organizations is only sent on create, so the change is recorded in state without being applied
+				Config: testAccResourceUser_unmanagedAttributes(testUserVals, "Accounting"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_user.my-new-user", "organizations.0.department", "Accounting"),
+				),
+			},
+		},
+	})
+}
+
+func TestIsUserAttributeOwned(t *testing.T) {
+	cases := []struct {
+		managed, unmanaged []string
+		attr               string
+		expected           bool
+	}{
+		{attr: "phones", expected: true},
+		{managed: []string{"phones"}, attr: "phones", expected: true},
+		{managed: []string{"phones"}, attr: "organizations", expected: false},
+		{unmanaged: []string{"organizations"}, attr: "organizations", expected: false},
+		{unmanaged: []string{"organizations"}, attr: "phones", expected: true},
+		// attributes that are not ownable are always managed
+		{managed: []string{"phones"}, attr: "primary_email", expected: true},
+		{managed: []string{"phones"}, attr: "name", expected: true},
+	}
+
+	for _, c := range cases {
+		if actual := isUserAttributeOwned(c.managed, c.unmanaged, c.attr); actual != c.expected {
+			t.Errorf("isUserAttributeOwned(%v, %v, %q) = %t, expected %t", c.managed, c.unmanaged, c.attr, actual, c.expected)
+		}
+	}
+}
+
+func TestExpandUserPassword_writeOnly(t *testing.T) {
+	const password = "correct horse battery staple"
+
+	cases := map[string]map[string]interface{}{
+		"salted hash": {
+			"primary_email":       "jane@example.com",
+			"password":            password,
+			"password_write_only": true,
+		},
+		"password version": {
+			"primary_email":       "jane@example.com",
+			"password":            password,
+			"password_write_only": true,
+			"password_version":    "1",
+		},
+		"client side hash": {
+			"primary_email":       "jane@example.com",
+			"password":            password,
+			"password_write_only": true,
+			"hash_function":       "SHA-1",
+			"hash_password":       true,
+		},
+	}
+
+	for name, raw := range cases {
+		d := schema.TestResourceDataRaw(t, resourceUser().Schema, raw)
+		d.SetId("123")
+
+		sent, hashFunction, diags := expandUserPassword(d, password)
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected error: %v", name, diags)
+		}
+
+		if raw["hash_password"] == true {
+			if sent == password || hashFunction != "SHA-1" {
+				t.Errorf("%s: expected the password to be hashed client side, got %q (%s)", name, sent, hashFunction)
+			}
+		} else if sent != password {
+			t.Errorf("%s: expected the plaintext password to be sent", name)
+		}
+
+		for k, v := range d.State().Attributes {
+			if strings.Contains(v, password) {
+				t.Errorf("%s: plaintext password found in state attribute %s", name, k)
+			}
+		}
+
+		stored := d.Get("password").(string)
+		if raw["password_version"] != nil {
+			if stored != "" {
+				t.Errorf("%s: expected no password in state, got %q", name, stored)
+			}
+		} else if !passwordMatchesStateHash(stored, password) {
+			t.Errorf("%s: expected a salted hash of the password in state, got %q", name, stored)
+		}
+	}
+}
+
+func TestDiffSuppressWriteOnlyPassword(t *testing.T) {
+	const password = "correct horse battery staple"
+
+	hash, err := hashPasswordForState(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+		"primary_email":       "jane@example.com",
+		"password":            password,
+		"password_write_only": true,
+	})
+
+	if !diffSuppressWriteOnlyPassword("password", hash, password, d) {
+		t.Errorf("expected a matching password to be suppressed")
+	}
+
+	if diffSuppressWriteOnlyPassword("password", hash, "another password", d) {
+		t.Errorf("expected a changed password to not be suppressed")
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+		"primary_email": "jane@example.com",
+		"password":      password,
+	})
+
+	if diffSuppressWriteOnlyPassword("password", hash, password, d) {
+		t.Errorf("expected the password to be compared as plaintext when password_write_only is not set")
+	}
+}
+
+func TestFindDeletedUser(t *testing.T) {
+	deletedUsers := []*directory.User{
+		{Id: "1", PrimaryEmail: "jane@example.com", DeletionTime: "2026-01-01T00:00:00.000Z"},
+		{Id: "2", PrimaryEmail: "Jane@Example.com", DeletionTime: "2026-01-05T00:00:00.000Z"},
+		{Id: "3", PrimaryEmail: "john@example.com", DeletionTime: "2026-01-10T00:00:00.000Z"},
+	}
+
+	if user := findDeletedUser(deletedUsers, "jane@example.com"); user == nil || user.Id != "2" {
+		t.Errorf("expected the most recently deleted user 2, got %+v", user)
+	}
+
+	if user := findDeletedUser(deletedUsers, "jim@example.com"); user != nil {
+		t.Errorf("expected no deleted user, got %+v", user)
+	}
+}
+
+func TestExpandUserDataTransfer(t *testing.T) {
+	applications := []*datatransfer.Application{
+		{Id: 1, Name: "Drive and Docs"},
+		{Id: 2, Name: "Google Data Studio"},
+		{Id: 3, Name: "Calendar"},
+	}
+
+	transfer, err := expandUserDataTransfer(applications, "old", "new")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if transfer.OldOwnerUserId != "old" || transfer.NewOwnerUserId != "new" {
+		t.Errorf("unexpected owners %q -> %q", transfer.OldOwnerUserId, transfer.NewOwnerUserId)
+	}
+
+	var ids []int64
+	for _, appTransfer := range transfer.ApplicationDataTransfers {
+		ids = append(ids, appTransfer.ApplicationId)
+	}
+	if !reflect.DeepEqual(ids, []int64{1, 3}) {
+		t.Errorf("expected Drive and Calendar to be transferred, got application ids %v", ids)
+	}
+
+	if _, err := expandUserDataTransfer(applications[:2], "old", "new"); err == nil {
+		t.Errorf("expected an error when the Calendar application is missing")
+	}
+}
+
+func TestFindResumableDataTransfer(t *testing.T) {
+	transfers := []*datatransfer.DataTransfer{
+		{Id: "a", OverallTransferStatusCode: "failed"},
+		{Id: "b", OverallTransferStatusCode: "inProgress"},
+		{Id: "c", OverallTransferStatusCode: "completed"},
+	}
+
+	if transfer := findResumableDataTransfer(transfers); transfer == nil || transfer.Id != "c" {
+		t.Errorf("expected completed transfer c, got %+v", transfer)
+	}
+
+	if transfer := findResumableDataTransfer(transfers[:2]); transfer == nil || transfer.Id != "b" {
+		t.Errorf("expected pending transfer b, got %+v", transfer)
+	}
+
+	if transfer := findResumableDataTransfer(transfers[:1]); transfer != nil {
+		t.Errorf("expected no transfer, got %+v", transfer)
+	}
+}
+
+func TestValidateUserDeletionPolicy(t *testing.T) {
+	getRecipient := func(recipient string) error {
+		if recipient == "missing@example.com" {
+			return &googleapi.Error{Code: 404, Message: "Resource Not Found: userKey"}
+		}
+		return nil
+	}
+
+	cases := map[string]struct {
+		policy    string
+		recipient string
+		err       string
+	}{
+		"delete":            {policy: "DELETE"},
+		"transfer":          {policy: "TRANSFER_THEN_DELETE", recipient: "manager@example.com"},
+		"missing recipient": {policy: "TRANSFER_THEN_DELETE", err: "transfer_recipient is required"},
+		"unknown recipient": {policy: "TRANSFER_THEN_DELETE", recipient: "missing@example.com", err: "was not found"},
+	}
+
+	for name, c := range cases {
+		err := validateUserDeletionPolicy(c.policy, c.recipient, getRecipient)
+		if c.err == "" && err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+			t.Errorf("%s: expected an error containing %q, got %v", name, c.err, err)
+		}
+	}
+}
+
 func testAccResourceUser_basic(testUserVals map[string]interface{}) string {
 	return Nprintf(`
 resource "googleworkspace_user" "my-new-user" {
@@ -325,6 +656,62 @@ resource "googleworkspace_user" "my-new-user" {
 `, testUserVals)
 }
 
+func testAccResourceUser_restoreIfDeleted(testUserVals map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_user" "my-new-user" {
+  primary_email      = "%{userEmail}@%{domainName}"
+  password           = "%{password}"
+  restore_if_deleted = true
+
+  name {
+    family_name = "Scott"
+    given_name  = "Michael"
+  }
+}
+`, testUserVals)
+}
+
+func testAccResourceUser_writeOnlyPassword(testUserVals map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_user" "my-new-user" {
+  primary_email       = "%{userEmail}@%{domainName}"
+  password            = "%{password}"
+  password_write_only = true
+  hash_function       = "crypt"
+  hash_password       = true
+
+  name {
+    family_name = "Scott"
+    given_name  = "Michael"
+  }
+}
+`, testUserVals)
+}
+
+func testAccResourceUser_unmanagedAttributes(testUserVals map[string]interface{}, department string) string {
+	testUserVals["department"] = department
+
+	return Nprintf(`
+resource "googleworkspace_user" "my-new-user" {
+  primary_email = "%{userEmail}@%{domainName}"
+  password      = "%{password}"
+
+  name {
+    family_name = "Scott"
+    given_name  = "Michael"
+  }
+
+  organizations {
+    department = "%{department}"
+    primary    = true
+    type       = "work"
+  }
+
+  unmanaged_attributes = ["organizations", "phones"]
+}
+`, testUserVals)
+}
+
 func testAccResourceUser_noPassword(testUserVals map[string]interface{}) string {
 	return Nprintf(`
 resource "googleworkspace_user" "my-new-user" {
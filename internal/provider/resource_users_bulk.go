@@ -0,0 +1,960 @@
+package googleworkspace
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+const (
+	usersBulkStatusCreated   = "CREATED"
+	usersBulkStatusUpdated   = "UPDATED"
+	usersBulkStatusUnchanged = "UNCHANGED"
+	usersBulkStatusFailed    = "FAILED"
+)
+
+// usersBulkColumns are the record attributes that can be provided as CSV columns or JSON keys,
+// custom schema values are provided as `<schema_name>.<field_name>` CSV columns.
+var usersBulkColumns = []string{"primary_email", "password", "hash_function", "given_name", "family_name",
+	"org_unit_path", "suspended", "change_password_at_next_login", "recovery_email", "recovery_phone"}
+
+func resourceUsersBulk() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Users Bulk resource manages a large set of Google Workspace Users as a single unit. " +
+			"Records are provided inline or from a CSV or JSON file and are created and updated concurrently. " +
+			"With `source_file`, only a hash and an ETag per record are stored in state. Inline `users` " +
+			"records are stored in state in full, including their passwords. Users Bulk resides " +
+			"under the `https://www.googleapis.com/auth/admin.directory.user` client scope.",
+
+		CreateContext: resourceUsersBulkCreate,
+		ReadContext:   resourceUsersBulkRead,
+		UpdateContext: resourceUsersBulkUpdate,
+		DeleteContext: resourceUsersBulkDelete,
+
+		CustomizeDiff: resourceUsersBulkCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"users": {
+				Description:  "The user records to manage. Conflicts with `source_file`.",
+				Type:         schema.TypeList,
+				Optional:     true,
+				ExactlyOneOf: []string{"users", "source_file"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"primary_email": {
+							Description: "The user's primary email address. Records are keyed by this value.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"password": {
+							Description: "The password of the user. It is only sent when the user is created, " +
+								"and is not part of the record hash.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Sensitive:        true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(8, 100)),
+						},
+						"hash_function": {
+							Description: "Stores the hash format of the password property. Acceptable values are " +
+								"`MD5`, `SHA-1` and `crypt`.",
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateDiagFunc: validation.ToDiagFunc(
+								validation.StringInSlice([]string{"MD5", "SHA-1", "crypt"}, false),
+							),
+						},
+						"given_name": {
+							Description: "The user's first name.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"family_name": {
+							Description: "The user's last name.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"org_unit_path": {
+							Description: "The full path of the parent organization associated with the user. " +
+								"If unset, the org unit of the user is not managed.",
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"suspended": {
+							Description: "Indicates if user is suspended.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+						"change_password_at_next_login": {
+							Description: "Indicates if the user is forced to change their password at next login. " +
+								"It is only sent when the user is created.",
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"recovery_email": {
+							Description: "Recovery email of the user.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"recovery_phone": {
+							Description: "Recovery phone of the user. The phone number must be in the E.164 format, " +
+								"starting with the plus sign (+). Example: +16506661212.",
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"custom_schemas": {
+							Description: "Custom fields of the user.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"schema_name": {
+										Description: "The name of the schema.",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+									"schema_values": {
+										Description: "JSON encoded map that represents key/value pairs that " +
+											"correspond to the given schema. ",
+										Type:     schema.TypeMap,
+										Required: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+											ValidateDiagFunc: validation.ToDiagFunc(
+												validation.StringIsJSON,
+											),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"source_file": {
+				Description: "Path to a CSV or JSON file containing the user records. CSV files must have a header " +
+					"row using the attribute names of `users` as columns, custom schema values are provided as " +
+					"`<schema_name>.<field_name>` columns. JSON files must contain a list of objects using the " +
+					"attribute names of `users` as keys, with `custom_schemas` as a map of schema names to field values.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"users", "source_file"},
+			},
+			"source_format": {
+				Description: "The format of `source_file`, either `CSV` or `JSON`. If unset, the format is detected " +
+					"from the file extension.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"CSV", "JSON"}, true)),
+			},
+			"concurrency": {
+				Description:      "The maximum number of users created or updated in parallel.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          10,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 50)),
+			},
+			"delete_users": {
+				Description: "If true, users removed from the records, or all users when the resource is destroyed, " +
+					"are deleted. Otherwise they are only removed from the Terraform state.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"record_hashes": {
+				Description: "A map of primary emails to the hash of the last successfully applied record. " +
+					"Records missing from this map, or with an empty hash because the user was changed outside " +
+					"of Terraform, are applied on the next apply.",
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"etags": {
+				Description: "A map of primary emails to the ETag of the user. A changed ETag marks the record " +
+					"to be applied again.",
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"status": {
+				Description: "A map of primary emails to the result of the last apply for the record. " +
+					"One of `CREATED`, `UPDATED`, `UNCHANGED` or `FAILED`.",
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+type usersBulkResult struct {
+	email  string
+	hash   string
+	status string
+	err    error
+}
+
+func resourceUsersBulkCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("users") || !d.NewValueKnown("source_file") {
+		for _, k := range []string{"record_hashes", "etags", "status"} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	records, err := usersBulkRecords(d)
+	if err != nil {
+		return err
+	}
+
+	desired := map[string]interface{}{}
+	for _, record := range records {
+		hash, err := hashUsersBulkRecord(record)
+		if err != nil {
+			return err
+		}
+		desired[usersBulkRecordEmail(record)] = hash
+	}
+
+	if reflect.DeepEqual(desired, d.Get("record_hashes").(map[string]interface{})) {
+		return nil
+	}
+
+	// failed records are left out of the applied hashes, so the new values are only known after apply
+	for _, k := range []string{"record_hashes", "etags", "status"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceUsersBulkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Creating Users Bulk")
+
+	d.SetId(resource.UniqueId())
+
+	diags := resourceUsersBulkApply(ctx, d, meta, d.Timeout(schema.TimeoutCreate))
+
+	log.Printf("[DEBUG] Finished creating Users Bulk %q", d.Id())
+
+	return diags
+}
+
+func resourceUsersBulkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	log.Printf("[DEBUG] Getting Users Bulk %q", d.Id())
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	current, err := listUserEtags(ctx, usersService, client.Customer)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hashes := d.Get("record_hashes").(map[string]interface{})
+	etags := d.Get("etags").(map[string]interface{})
+
+	reconcileUsersBulkEtags(hashes, etags, current)
+
+	d.Set("record_hashes", hashes)
+	d.Set("etags", etags)
+
+	log.Printf("[DEBUG] Finished getting Users Bulk %q", d.Id())
+
+	return diags
+}
+
+func resourceUsersBulkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Updating Users Bulk %q", d.Id())
+
+	diags := resourceUsersBulkApply(ctx, d, meta, d.Timeout(schema.TimeoutUpdate))
+
+	log.Printf("[DEBUG] Finished updating Users Bulk %q", d.Id())
+
+	return diags
+}
+
+func resourceUsersBulkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	log.Printf("[DEBUG] Deleting Users Bulk %q", d.Id())
+
+	if !d.Get("delete_users").(bool) {
+		log.Printf("[DEBUG] Removing Users Bulk %q from state without deleting users", d.Id())
+		return diags
+	}
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	var emails []string
+	for email := range d.Get("record_hashes").(map[string]interface{}) {
+		emails = append(emails, email)
+	}
+
+	err := deleteUsersConcurrently(ctx, usersService, emails, d.Get("concurrency").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finished deleting Users Bulk %q", d.Id())
+
+	return diags
+}
+
+// reconcileUsersBulkEtags drops the users that are gone from the applied hashes and etags. Users
+// changed outside of this resource keep their key, which records that they're managed, with an
+// empty hash so that the record is applied again.
+func reconcileUsersBulkEtags(hashes, etags map[string]interface{}, current map[string]string) {
+	for email := range hashes {
+		etag, ok := current[email]
+		if !ok {
+			log.Printf("[WARN] User %q of Users Bulk is gone", email)
+			delete(hashes, email)
+			delete(etags, email)
+			continue
+		}
+
+		if etags[email] != etag {
+			log.Printf("[DEBUG] User %q of Users Bulk has changed (etag %q -> %q)", email, etags[email], etag)
+			hashes[email] = ""
+		}
+	}
+}
+
+// resourceUsersBulkApply creates or updates every record whose hash differs from the last
+// applied hash, deletes removed records if configured, and stores the per-record results.
+// Failed records are reported as warnings and are retried on the next apply.
+func resourceUsersBulkApply(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	records, err := usersBulkRecords(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	schemasService, diags := GetSchemasService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	// the planned values of these maps are unknown, use the values in state
+	oldHashesRaw, _ := d.GetChange("record_hashes")
+	oldHashes := oldHashesRaw.(map[string]interface{})
+	oldEtagsRaw, _ := d.GetChange("etags")
+	oldEtags := oldEtagsRaw.(map[string]interface{})
+
+	getSchema := cachedSchemaGetter(func(schemaName string) (*directory.Schema, error) {
+		return schemasService.Get(client.Customer, schemaName).Do()
+	})
+
+	concurrency := d.Get("concurrency").(int)
+	results := make([]usersBulkResult, len(records))
+	err = forEachConcurrently(ctx, len(records), concurrency, func(ctx context.Context, i int) error {
+		results[i] = applyUsersBulkRecord(ctx, usersService, getSchema, records[i], oldHashes)
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hashes := map[string]interface{}{}
+	etags := map[string]interface{}{}
+	status := map[string]interface{}{}
+	var written []string
+	var failures []string
+
+	for _, result := range results {
+		status[result.email] = result.status
+
+		switch result.status {
+		case usersBulkStatusFailed:
+			failures = append(failures, fmt.Sprintf("%s: %s", result.email, result.err))
+			// keep the previously applied hash (if any) so the record keeps showing a diff
+			if hash, ok := oldHashes[result.email]; ok {
+				hashes[result.email] = hash
+				etags[result.email] = oldEtags[result.email]
+			}
+		case usersBulkStatusUnchanged:
+			hashes[result.email] = result.hash
+			etags[result.email] = oldEtags[result.email]
+		default:
+			hashes[result.email] = result.hash
+			written = append(written, result.email)
+		}
+	}
+
+	// Users that are no longer part of the records
+	var removed []string
+	for email := range oldHashes {
+		if _, ok := status[email]; !ok {
+			removed = append(removed, email)
+		}
+	}
+
+	var deleteErr error
+	if len(removed) > 0 && d.Get("delete_users").(bool) {
+		deleteErr = deleteUsersConcurrently(ctx, usersService, removed, concurrency)
+		if deleteErr != nil {
+			// the removed users stay tracked so that they are deleted on the next apply, the
+			// ones that were deleted are dropped by the next read
+			for _, email := range removed {
+				hashes[email] = oldHashes[email]
+				etags[email] = oldEtags[email]
+			}
+		}
+	}
+
+	if len(written) > 0 {
+		// the records were written, so a timeout only means that the recorded etags may be stale,
+		// in which case the next read applies the records again
+		currentEtags, err := waitForUserEtags(ctx, usersService, client.Customer, written, timeout)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "user records were applied but did not become consistent",
+				Detail:   err.Error(),
+			})
+		}
+
+		for _, email := range written {
+			etags[email] = currentEtags[email]
+		}
+	}
+
+	d.Set("record_hashes", hashes)
+	d.Set("etags", etags)
+	d.Set("status", status)
+
+	if deleteErr != nil {
+		return append(diags, diag.FromErr(deleteErr)...)
+	}
+
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%d of %d user records failed to apply and will be retried on the next apply", len(failures), len(records)),
+			Detail:   strings.Join(failures, "\n"),
+		})
+	}
+
+	return diags
+}
+
+// applyUsersBulkRecord creates or updates a single record. Records already managed are
+// updated, unless they were deleted outside of Terraform, and new records are created,
+// unless the user already exists.
+func applyUsersBulkRecord(ctx context.Context, usersService *directory.UsersService, getSchema func(string) (*directory.Schema, error), record map[string]interface{}, oldHashes map[string]interface{}) usersBulkResult {
+	result := usersBulkResult{
+		email:  usersBulkRecordEmail(record),
+		status: usersBulkStatusFailed,
+	}
+
+	hash, err := hashUsersBulkRecord(record)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	result.hash = hash
+
+	oldHash, managed := oldHashes[result.email]
+	if managed && oldHash == hash {
+		result.status = usersBulkStatusUnchanged
+		return result
+	}
+
+	if customSchemas, ok := record["custom_schemas"].([]interface{}); ok && len(customSchemas) > 0 {
+		if diags := validateCustomSchemaValues(customSchemas, getSchema); diags.HasError() {
+			result.err = fmt.Errorf("%s", diags[0].Summary)
+			return result
+		}
+	}
+
+	userObj, err := expandUsersBulkRecord(record)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	if managed {
+		log.Printf("[DEBUG] Updating User %q", result.email)
+		_, err = usersService.Update(result.email, userObj).Context(ctx).Do()
+		if isApiErrorWithCode(err, 404) {
+			log.Printf("[DEBUG] User %q is gone, creating it", result.email)
+			managed = false
+		} else if err == nil {
+			result.status = usersBulkStatusUpdated
+		}
+	}
+
+	if !managed {
+		log.Printf("[DEBUG] Creating User %q", result.email)
+		userObj.Password = usersBulkString(record, "password")
+		userObj.HashFunction = usersBulkString(record, "hash_function")
+		userObj.ChangePasswordAtNextLogin = usersBulkBool(record, "change_password_at_next_login")
+
+		_, err = usersService.Insert(userObj).Context(ctx).Do()
+		if isApiErrorWithCode(err, 409) {
+			log.Printf("[DEBUG] User %q already exists, updating it", result.email)
+			userObj.Password = ""
+			userObj.HashFunction = ""
+			userObj.ChangePasswordAtNextLogin = false
+			_, err = usersService.Update(result.email, userObj).Context(ctx).Do()
+			if err == nil {
+				result.status = usersBulkStatusUpdated
+			}
+		} else if err == nil {
+			result.status = usersBulkStatusCreated
+		}
+	}
+
+	result.err = err
+	return result
+}
+
+func expandUsersBulkRecord(record map[string]interface{}) (*directory.User, error) {
+	userObj := &directory.User{
+		PrimaryEmail: usersBulkRecordEmail(record),
+		Name: &directory.UserName{
+			GivenName:  usersBulkString(record, "given_name"),
+			FamilyName: usersBulkString(record, "family_name"),
+		},
+		OrgUnitPath:   usersBulkString(record, "org_unit_path"),
+		RecoveryEmail: usersBulkString(record, "recovery_email"),
+		RecoveryPhone: usersBulkString(record, "recovery_phone"),
+		Suspended:     usersBulkBool(record, "suspended"),
+
+		ForceSendFields: []string{"Suspended"},
+	}
+
+	if customSchemas, ok := record["custom_schemas"].([]interface{}); ok && len(customSchemas) > 0 {
+		values, diags := expandCustomSchemaValues(customSchemas)
+		if diags.HasError() {
+			return nil, fmt.Errorf("%s", diags[0].Summary)
+		}
+
+		userObj.CustomSchemas = values
+	}
+
+	return userObj, nil
+}
+
+func deleteUsersConcurrently(ctx context.Context, usersService *directory.UsersService, emails []string, concurrency int) error {
+	return forEachConcurrently(ctx, len(emails), concurrency, func(ctx context.Context, i int) error {
+		log.Printf("[DEBUG] Deleting User %q", emails[i])
+
+		err := usersService.Delete(emails[i]).Context(ctx).Do()
+		if err != nil && !isApiErrorWithCode(err, 404) {
+			return fmt.Errorf("error deleting user %s: %w", emails[i], err)
+		}
+
+		return nil
+	})
+}
+
+// listUserEtags returns the etag of every user of the customer keyed by lower cased primary email.
+func listUserEtags(ctx context.Context, usersService *directory.UsersService, customer string) (map[string]string, error) {
+	etags := map[string]string{}
+
+	err := usersService.List().Customer(customer).Fields("nextPageToken", "users(primaryEmail,etag)").MaxResults(500).
+		Pages(ctx, func(resp *directory.Users) error {
+			for _, user := range resp.Users {
+				etags[strings.ToLower(user.PrimaryEmail)] = user.Etag
+			}
+
+			return nil
+		})
+
+	return etags, err
+}
+
+// waitForUserEtags lists the users of the customer until the etags of the given users are
+// consistent, and returns the last listed etags.
+func waitForUserEtags(ctx context.Context, usersService *directory.UsersService, customer string, emails []string, timeout time.Duration) (map[string]string, error) {
+	var etags map[string]string
+
+	sort.Strings(emails)
+
+	cc := consistencyCheck{
+		resourceType: "users",
+		timeout:      timeout,
+	}
+	err := retryTimeDuration(ctx, timeout, func() error {
+		var retryErr error
+
+		if cc.reachedConsistency(1) {
+			return nil
+		}
+
+		etags, retryErr = listUserEtags(ctx, usersService, customer)
+		if retryErr != nil {
+			return fmt.Errorf("unexpected error during retries of %s: %s", cc.resourceType, retryErr)
+		}
+
+		hash := sha256.New()
+		for _, email := range emails {
+			hash.Write([]byte(email + "=" + etags[email] + "\n"))
+		}
+		combined := hex.EncodeToString(hash.Sum(nil))
+
+		if combined == cc.lastEtag {
+			cc.currConsistent += 1
+		} else {
+			cc.handleNewEtag(combined)
+		}
+
+		return fmt.Errorf("timed out while waiting for %s to be updated", cc.resourceType)
+	})
+
+	return etags, err
+}
+
+// cachedSchemaGetter wraps getSchema so that every schema definition is only retrieved once.
+// It is safe for concurrent use.
+func cachedSchemaGetter(getSchema func(string) (*directory.Schema, error)) func(string) (*directory.Schema, error) {
+	var mu sync.Mutex
+	cache := map[string]*directory.Schema{}
+
+	return func(schemaName string) (*directory.Schema, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if schemaDef, ok := cache[schemaName]; ok {
+			return schemaDef, nil
+		}
+
+		schemaDef, err := getSchema(schemaName)
+		if err != nil {
+			return nil, err
+		}
+
+		cache[schemaName] = schemaDef
+		return schemaDef, nil
+	}
+}
+
+// resourceGetter is implemented by both schema.ResourceData and schema.ResourceDiff
+type resourceGetter interface {
+	Get(string) interface{}
+}
+
+// usersBulkRecords returns the configured user records, either from `users` or
+// parsed from `source_file`, in the same shape as the elements of `users`.
+func usersBulkRecords(d resourceGetter) ([]map[string]interface{}, error) {
+	var records []map[string]interface{}
+
+	if sourceFile := d.Get("source_file").(string); sourceFile != "" {
+		contents, err := os.ReadFile(sourceFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %q: %v", sourceFile, err)
+		}
+
+		format := strings.ToUpper(d.Get("source_format").(string))
+		if format == "" {
+			format = strings.ToUpper(strings.TrimPrefix(filepath.Ext(sourceFile), "."))
+		}
+
+		switch format {
+		case "CSV":
+			records, err = parseUsersBulkCSV(strings.NewReader(string(contents)))
+		case "JSON":
+			records, err = parseUsersBulkJSON(contents)
+		default:
+			return nil, fmt.Errorf("unable to detect the format of %q, set source_format to CSV or JSON", sourceFile)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %q: %v", sourceFile, err)
+		}
+	} else {
+		for _, u := range d.Get("users").([]interface{}) {
+			if u == nil {
+				continue
+			}
+			records = append(records, u.(map[string]interface{}))
+		}
+	}
+
+	seen := map[string]bool{}
+	for i, record := range records {
+		email := usersBulkRecordEmail(record)
+		if email == "" || usersBulkString(record, "given_name") == "" || usersBulkString(record, "family_name") == "" {
+			return nil, fmt.Errorf("record %d: primary_email, given_name and family_name are required", i)
+		}
+
+		if seen[email] {
+			return nil, fmt.Errorf("record %d: duplicate primary_email %q", i, email)
+		}
+		seen[email] = true
+	}
+
+	return records, nil
+}
+
+// parseUsersBulkCSV parses CSV user records. Columns that are not record attributes
+// are treated as `<schema_name>.<field_name>` custom schema values, whose cells hold
+// JSON values or plain strings.
+func parseUsersBulkCSV(r io.Reader) ([]map[string]interface{}, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, column := range header {
+		if !stringInSlice(usersBulkColumns, column) && !strings.Contains(column, ".") {
+			return nil, fmt.Errorf("unknown column %q", column)
+		}
+	}
+
+	var records []map[string]interface{}
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		record := map[string]interface{}{}
+		customSchemas := map[string]map[string]interface{}{}
+
+		for i, column := range header {
+			cell := strings.TrimSpace(row[i])
+
+			switch column {
+			case "suspended", "change_password_at_next_login":
+				value := false
+				if cell != "" {
+					value, err = strconv.ParseBool(cell)
+					if err != nil {
+						return nil, fmt.Errorf("line %d: invalid %s %q", len(records)+2, column, cell)
+					}
+				}
+				record[column] = value
+			default:
+				if stringInSlice(usersBulkColumns, column) {
+					record[column] = cell
+					continue
+				}
+
+				if cell == "" {
+					continue
+				}
+
+				parts := strings.SplitN(column, ".", 2)
+				if _, ok := customSchemas[parts[0]]; !ok {
+					customSchemas[parts[0]] = map[string]interface{}{}
+				}
+
+				// plain strings are accepted as well as JSON values
+				if !json.Valid([]byte(cell)) {
+					encoded, err := json.Marshal(cell)
+					if err != nil {
+						return nil, err
+					}
+					cell = string(encoded)
+				}
+				customSchemas[parts[0]][parts[1]] = cell
+			}
+		}
+
+		record["custom_schemas"] = flattenUsersBulkCustomSchemas(customSchemas)
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// parseUsersBulkJSON parses a JSON list of user records.
+func parseUsersBulkJSON(contents []byte) ([]map[string]interface{}, error) {
+	var rawRecords []map[string]json.RawMessage
+	if err := json.Unmarshal(contents, &rawRecords); err != nil {
+		return nil, err
+	}
+
+	var records []map[string]interface{}
+	for i, rawRecord := range rawRecords {
+		record := map[string]interface{}{}
+		customSchemas := map[string]map[string]interface{}{}
+
+		for key, raw := range rawRecord {
+			switch key {
+			case "custom_schemas":
+				var schemas map[string]map[string]json.RawMessage
+				if err := json.Unmarshal(raw, &schemas); err != nil {
+					return nil, fmt.Errorf("record %d: custom_schemas must be a map of schema names to field values: %v", i, err)
+				}
+
+				for schemaName, fields := range schemas {
+					customSchemas[schemaName] = map[string]interface{}{}
+					for fieldName, value := range fields {
+						customSchemas[schemaName][fieldName] = string(value)
+					}
+				}
+			case "suspended", "change_password_at_next_login":
+				var value bool
+				if err := json.Unmarshal(raw, &value); err != nil {
+					return nil, fmt.Errorf("record %d: %s must be a boolean", i, key)
+				}
+				record[key] = value
+			default:
+				if !stringInSlice(usersBulkColumns, key) {
+					return nil, fmt.Errorf("record %d: unknown key %q", i, key)
+				}
+
+				var value string
+				if err := json.Unmarshal(raw, &value); err != nil {
+					return nil, fmt.Errorf("record %d: %s must be a string", i, key)
+				}
+				record[key] = value
+			}
+		}
+
+		record["custom_schemas"] = flattenUsersBulkCustomSchemas(customSchemas)
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// flattenUsersBulkCustomSchemas converts a map of schema names to JSON encoded field values
+// into the shape of `custom_schemas` blocks.
+func flattenUsersBulkCustomSchemas(customSchemas map[string]map[string]interface{}) []interface{} {
+	schemaNames := make([]string, 0, len(customSchemas))
+	for schemaName := range customSchemas {
+		schemaNames = append(schemaNames, schemaName)
+	}
+	sort.Strings(schemaNames)
+
+	result := []interface{}{}
+	for _, schemaName := range schemaNames {
+		result = append(result, map[string]interface{}{
+			"schema_name":   schemaName,
+			"schema_values": customSchemas[schemaName],
+		})
+	}
+
+	return result
+}
+
+// hashUsersBulkRecord returns a hash of the attributes of a record that are compared on
+// every apply. The password, hash function and change_password_at_next_login are only
+// used on creation and are not part of the hash.
+func hashUsersBulkRecord(record map[string]interface{}) (string, error) {
+	hashed := map[string]interface{}{}
+	for _, k := range usersBulkColumns {
+		switch k {
+		case "password", "hash_function", "change_password_at_next_login":
+			continue
+		case "primary_email":
+			hashed[k] = usersBulkRecordEmail(record)
+		default:
+			hashed[k] = record[k]
+		}
+	}
+
+	if customSchemas, ok := record["custom_schemas"].([]interface{}); ok && len(customSchemas) > 0 {
+		hashed["custom_schemas"] = transformCustomSchemasTo2DMap(customSchemas)
+	}
+
+	// json.Marshal sorts map keys, so equal records have equal encodings
+	encoded, err := json.Marshal(hashed)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(encoded)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func usersBulkRecordEmail(record map[string]interface{}) string {
+	return strings.ToLower(usersBulkString(record, "primary_email"))
+}
+
+func usersBulkString(record map[string]interface{}, key string) string {
+	v, _ := record[key].(string)
+	return v
+}
+
+func usersBulkBool(record map[string]interface{}, key string) bool {
+	v, _ := record[key].(bool)
+	return v
+}
@@ -0,0 +1,279 @@
+package googleworkspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func TestParseUsersBulkCSV(t *testing.T) {
+	input := `primary_email,given_name,family_name,org_unit_path,suspended,Employment.EmployeeNumber,Employment.Projects
+Jane@Example.com,Jane,Doe,/engineering,true,42,"[""a"",""b""]"
+john@example.com,John,Doe,,,,
+`
+
+	records, err := parseUsersBulkCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"primary_email": "Jane@Example.com",
+			"given_name":    "Jane",
+			"family_name":   "Doe",
+			"org_unit_path": "/engineering",
+			"suspended":     true,
+			"custom_schemas": []interface{}{
+				map[string]interface{}{
+					"schema_name": "Employment",
+					"schema_values": map[string]interface{}{
+						"EmployeeNumber": "42",
+						"Projects":       `["a","b"]`,
+					},
+				},
+			},
+		},
+		{
+			"primary_email":  "john@example.com",
+			"given_name":     "John",
+			"family_name":    "Doe",
+			"org_unit_path":  "",
+			"suspended":      false,
+			"custom_schemas": []interface{}{},
+		},
+	}
+
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("parsed records (%+v) did not match expected (%+v)", records, expected)
+	}
+}
+
+func TestParseUsersBulkCSV_invalid(t *testing.T) {
+	cases := map[string]string{
+		"unknown column": "primary_email,given_name,family_name,nickname\na@example.com,A,B,c\n",
+		"invalid bool":   "primary_email,given_name,family_name,suspended\na@example.com,A,B,maybe\n",
+	}
+
+	for name, input := range cases {
+		if _, err := parseUsersBulkCSV(strings.NewReader(input)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParseUsersBulkJSON(t *testing.T) {
+	input := `[{"primary_email": "jane@example.com", "given_name": "Jane", "family_name": "Doe", "suspended": true,
+  "custom_schemas": {"Employment": {"EmployeeNumber": 42, "Title": "Engineer"}}}]`
+
+	records, err := parseUsersBulkJSON([]byte(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"primary_email": "jane@example.com",
+			"given_name":    "Jane",
+			"family_name":   "Doe",
+			"suspended":     true,
+			"custom_schemas": []interface{}{
+				map[string]interface{}{
+					"schema_name": "Employment",
+					"schema_values": map[string]interface{}{
+						"EmployeeNumber": "42",
+						"Title":          `"Engineer"`,
+					},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("parsed records (%+v) did not match expected (%+v)", records, expected)
+	}
+
+	if _, err := parseUsersBulkJSON([]byte(`[{"primary_email": "a@example.com", "nickname": "a"}]`)); err == nil {
+		t.Errorf("expected an error for an unknown key")
+	}
+}
+
+func TestHashUsersBulkRecord(t *testing.T) {
+	record := func(email, password, projects string) map[string]interface{} {
+		return map[string]interface{}{
+			"primary_email": email,
+			"password":      password,
+			"given_name":    "Jane",
+			"family_name":   "Doe",
+			"custom_schemas": []interface{}{
+				map[string]interface{}{
+					"schema_name":   "Employment",
+					"schema_values": map[string]interface{}{"Projects": projects},
+				},
+			},
+		}
+	}
+
+	hash := func(r map[string]interface{}) string {
+		h, err := hashUsersBulkRecord(r)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return h
+	}
+
+	base := hash(record("jane@example.com", "password1", `["a","b"]`))
+
+	if base != hash(record("JANE@example.com", "password2", `["b", "a"]`)) {
+		t.Errorf("expected email case, password and multi-value ordering to not change the hash")
+	}
+
+	if base == hash(record("jane@example.com", "password1", `["a","c"]`)) {
+		t.Errorf("expected a changed custom schema value to change the hash")
+	}
+}
+
+func TestUsersBulkRecords_duplicate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "users.csv")
+	err := os.WriteFile(path, []byte("primary_email,given_name,family_name\na@example.com,A,B\nA@example.com,A,B\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := resourceUsersBulk().TestResourceData()
+	d.Set("source_file", path)
+
+	if _, err := usersBulkRecords(d); err == nil || !strings.Contains(err.Error(), "duplicate") {
+		t.Errorf("expected a duplicate primary_email error, got %v", err)
+	}
+}
+
+func TestCachedSchemaGetter(t *testing.T) {
+	calls := 0
+	getSchema := cachedSchemaGetter(func(schemaName string) (*directory.Schema, error) {
+		calls++
+		return &directory.Schema{SchemaName: schemaName}, nil
+	})
+
+	for i := 0; i < 3; i++ {
+		schemaDef, err := getSchema("Employment")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if schemaDef.SchemaName != "Employment" {
+			t.Errorf("unexpected schema %q", schemaDef.SchemaName)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("expected the schema to be retrieved once, got %d calls", calls)
+	}
+}
+
+func TestReconcileUsersBulkEtags(t *testing.T) {
+	hashes := map[string]interface{}{
+		"same@example.com":    "hash-1",
+		"changed@example.com": "hash-2",
+		"gone@example.com":    "hash-3",
+	}
+	etags := map[string]interface{}{
+		"same@example.com":    "etag-1",
+		"changed@example.com": "etag-2",
+		"gone@example.com":    "etag-3",
+	}
+
+	reconcileUsersBulkEtags(hashes, etags, map[string]string{
+		"same@example.com":    "etag-1",
+		"changed@example.com": "etag-2-edited",
+		"other@example.com":   "etag-4",
+	})
+
+	// the changed user is still owned, so that it's deleted with the resource, but is applied again
+	expectedHashes := map[string]interface{}{
+		"same@example.com":    "hash-1",
+		"changed@example.com": "",
+	}
+	if !reflect.DeepEqual(hashes, expectedHashes) {
+		t.Errorf("hashes not equal\n\nactual %v\n\nexpected %v", hashes, expectedHashes)
+	}
+
+	expectedEtags := map[string]interface{}{
+		"same@example.com":    "etag-1",
+		"changed@example.com": "etag-2",
+	}
+	if !reflect.DeepEqual(etags, expectedEtags) {
+		t.Errorf("etags not equal\n\nactual %v\n\nexpected %v", etags, expectedEtags)
+	}
+}
+
+func TestAccResourceUsersBulk_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testUserVals := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"password":   acctest.RandString(10),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceUsersBulk_basic(testUserVals, "Doe"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_users_bulk.hr", "record_hashes.%", "2"),
+					resource.TestCheckResourceAttr("googleworkspace_users_bulk.hr", "etags.%", "2"),
+					resource.TestCheckResourceAttr("googleworkspace_users_bulk.hr",
+						fmt.Sprintf("status.%s-1@%s", testUserVals["userEmail"], domainName), "CREATED"),
+				),
+			},
+			{
+				Config: testAccResourceUsersBulk_basic(testUserVals, "Smith"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_users_bulk.hr",
+						fmt.Sprintf("status.%s-1@%s", testUserVals["userEmail"], domainName), "UPDATED"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceUsersBulk_basic(testUserVals map[string]interface{}, familyName string) string {
+	testUserVals["familyName"] = familyName
+
+	return Nprintf(`
+resource "googleworkspace_users_bulk" "hr" {
+  delete_users = true
+
+  users {
+    primary_email = "%{userEmail}-1@%{domainName}"
+    password      = "%{password}"
+    given_name    = "John"
+    family_name   = "%{familyName}"
+  }
+
+  users {
+    primary_email = "%{userEmail}-2@%{domainName}"
+    password      = "%{password}"
+    given_name    = "Jane"
+    family_name   = "Doe"
+    suspended     = true
+  }
+}
+`, testUserVals)
+}
@@ -5,13 +5,32 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
+	datatransfer "google.golang.org/api/admin/datatransfer/v1"
 	directory "google.golang.org/api/admin/directory/v1"
 	"google.golang.org/api/chromepolicy/v1"
 	"google.golang.org/api/cloudidentity/v1"
 	"google.golang.org/api/gmail/v1"
 	"google.golang.org/api/groupssettings/v1"
+	"google.golang.org/api/siteverification/v1"
 )
 
+func GetAspsService(directoryService *directory.Service) (*directory.AspsService, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	log.Printf("[INFO] Instantiating Google Admin Asps service")
+	aspsService := directoryService.Asps
+	if aspsService == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Asps Service could not be created.",
+		})
+
+		return nil, diags
+	}
+
+	return aspsService, diags
+}
+
 func GetChromePoliciesService(chromePolicyService *chromepolicy.Service) (*chromepolicy.CustomersPoliciesService, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
@@ -63,6 +82,23 @@ func GetChromePolicyGroupsService(chromePolicyService *chromepolicy.Service) (*c
 	return customersService.Policies.Groups, diags
 }
 
+func GetChromeosDevicesService(directoryService *directory.Service) (*directory.ChromeosdevicesService, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	log.Printf("[INFO] Instantiating Google Admin Chrome OS Devices service")
+	chromeosDevicesService := directoryService.Chromeosdevices
+	if chromeosDevicesService == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Chrome OS Devices Service could not be created.",
+		})
+
+		return nil, diags
+	}
+
+	return chromeosDevicesService, diags
+}
+
 func GetCloudIdentityGroupsService(cloudIdentityService *cloudidentity.Service) (*cloudidentity.GroupsService, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
@@ -80,6 +116,40 @@ func GetCloudIdentityGroupsService(cloudIdentityService *cloudidentity.Service)
 	return groupsService, diags
 }
 
+func GetDataTransferApplicationsService(dataTransferService *datatransfer.Service) (*datatransfer.ApplicationsService, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	log.Printf("[INFO] Instantiating Google Admin Data Transfer Applications service")
+	applicationsService := dataTransferService.Applications
+	if applicationsService == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Data Transfer Applications Service could not be created.",
+		})
+
+		return nil, diags
+	}
+
+	return applicationsService, diags
+}
+
+func GetDataTransfersService(dataTransferService *datatransfer.Service) (*datatransfer.TransfersService, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	log.Printf("[INFO] Instantiating Google Admin Data Transfers service")
+	transfersService := dataTransferService.Transfers
+	if transfersService == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Data Transfers Service could not be created.",
+		})
+
+		return nil, diags
+	}
+
+	return transfersService, diags
+}
+
 func GetDomainAliasesService(directoryService *directory.Service) (*directory.DomainAliasesService, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
@@ -148,6 +218,57 @@ func GetGroupsSettingsService(groupsSettingsService *groupssettings.Service) (*g
 	return groupsService, diags
 }
 
+func GetGmailDelegatesService(gmailService *gmail.Service) (*gmail.UsersSettingsDelegatesService, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	log.Printf("[INFO] Instantiating Google Admin Gmail Delegates service")
+	usersService := gmailService.Users
+	if usersService == nil || usersService.Settings == nil || usersService.Settings.Delegates == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Delegates Service could not be created.",
+		})
+
+		return nil, diags
+	}
+
+	return usersService.Settings.Delegates, diags
+}
+
+func GetGmailFiltersService(gmailService *gmail.Service) (*gmail.UsersSettingsFiltersService, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	log.Printf("[INFO] Instantiating Google Admin Gmail Filters service")
+	usersService := gmailService.Users
+	if usersService == nil || usersService.Settings == nil || usersService.Settings.Filters == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Filters Service could not be created.",
+		})
+
+		return nil, diags
+	}
+
+	return usersService.Settings.Filters, diags
+}
+
+func GetGmailForwardingAddressesService(gmailService *gmail.Service) (*gmail.UsersSettingsForwardingAddressesService, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	log.Printf("[INFO] Instantiating Google Admin Gmail Forwarding Addresses service")
+	usersService := gmailService.Users
+	if usersService == nil || usersService.Settings == nil || usersService.Settings.ForwardingAddresses == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Forwarding Addresses Service could not be created.",
+		})
+
+		return nil, diags
+	}
+
+	return usersService.Settings.ForwardingAddresses, diags
+}
+
 func GetGmailSendAsAliasService(gmailService *gmail.Service) (*gmail.UsersSettingsSendAsService, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
@@ -165,6 +286,23 @@ func GetGmailSendAsAliasService(gmailService *gmail.Service) (*gmail.UsersSettin
 	return usersService.Settings.SendAs, diags
 }
 
+func GetGmailSettingsService(gmailService *gmail.Service) (*gmail.UsersSettingsService, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	log.Printf("[INFO] Instantiating Google Admin Gmail Settings service")
+	usersService := gmailService.Users
+	if usersService == nil || usersService.Settings == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Settings Service could not be created.",
+		})
+
+		return nil, diags
+	}
+
+	return usersService.Settings, diags
+}
+
 func GetGroupAliasService(groupsService *directory.GroupsService) (*directory.GroupsAliasesService, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
@@ -284,6 +422,40 @@ func GetSchemasService(directoryService *directory.Service) (*directory.SchemasS
 	return schemasService, diags
 }
 
+func GetTokensService(directoryService *directory.Service) (*directory.TokensService, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	log.Printf("[INFO] Instantiating Google Admin Tokens service")
+	tokensService := directoryService.Tokens
+	if tokensService == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Tokens Service could not be created.",
+		})
+
+		return nil, diags
+	}
+
+	return tokensService, diags
+}
+
+func GetTwoStepVerificationService(directoryService *directory.Service) (*directory.TwoStepVerificationService, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	log.Printf("[INFO] Instantiating Google Admin Two Step Verification service")
+	twoStepVerificationService := directoryService.TwoStepVerification
+	if twoStepVerificationService == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Two Step Verification Service could not be created.",
+		})
+
+		return nil, diags
+	}
+
+	return twoStepVerificationService, diags
+}
+
 func GetUsersService(directoryService *directory.Service) (*directory.UsersService, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
@@ -317,3 +489,54 @@ func GetUserAliasService(usersService *directory.UsersService) (*directory.Users
 
 	return aliasesService, diags
 }
+
+func GetUserPhotosService(usersService *directory.UsersService) (*directory.UsersPhotosService, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	log.Printf("[INFO] Instantiating Google Admin User Photos service")
+	photosService := usersService.Photos
+	if photosService == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Users Photos Service could not be created.",
+		})
+
+		return nil, diags
+	}
+
+	return photosService, diags
+}
+
+func GetVerificationCodesService(directoryService *directory.Service) (*directory.VerificationCodesService, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	log.Printf("[INFO] Instantiating Google Admin Verification Codes service")
+	verificationCodesService := directoryService.VerificationCodes
+	if verificationCodesService == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Verification Codes Service could not be created.",
+		})
+
+		return nil, diags
+	}
+
+	return verificationCodesService, diags
+}
+
+func GetWebResourceService(siteVerificationService *siteverification.Service) (*siteverification.WebResourceService, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	log.Printf("[INFO] Instantiating Google Site Verification Web Resource service")
+	webResourceService := siteVerificationService.WebResource
+	if webResourceService == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Web Resource Service could not be created.",
+		})
+
+		return nil, diags
+	}
+
+	return webResourceService, diags
+}
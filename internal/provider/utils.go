@@ -1,6 +1,7 @@
 package googleworkspace
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/mail"
@@ -9,6 +10,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 	"unicode"
 
 	"github.com/hashicorp/errwrap"
@@ -237,3 +240,66 @@ func isEmail(input string) bool {
 	_, err := mail.ParseAddress(input)
 	return err == nil
 }
+
+// formatUnixMilli formats a time in milliseconds since the epoch as RFC 3339, returning an empty
+// string for the zero value.
+func formatUnixMilli(ms int64) string {
+	if ms == 0 {
+		return ""
+	}
+
+	return time.UnixMilli(ms).UTC().Format(time.RFC3339)
+}
+
+// forEachConcurrently calls fn for every index in [0, n), running at most concurrency calls
+// at the same time. It waits for all started calls to return and reports the first error
+// encountered. Once an error is returned or ctx is cancelled no new calls are started.
+func forEachConcurrently(ctx context.Context, n, concurrency int, fn func(ctx context.Context, i int) error) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, concurrency)
+
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := fn(ctx, i); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
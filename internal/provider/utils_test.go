@@ -1,7 +1,11 @@
 package googleworkspace
 
 import (
+	"context"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestSnakeToCamel(t *testing.T) {
@@ -70,3 +74,56 @@ func TestIsEmail(t *testing.T) {
 		}
 	}
 }
+
+func TestForEachConcurrently(t *testing.T) {
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	visited := make([]bool, 20)
+
+	err := forEachConcurrently(context.Background(), len(visited), 3, func(ctx context.Context, i int) error {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		visited[i] = true
+		mu.Unlock()
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if maxRunning > 3 {
+		t.Errorf("expected at most 3 concurrent calls, got %d", maxRunning)
+	}
+
+	for i, v := range visited {
+		if !v {
+			t.Errorf("index %d was not visited", i)
+		}
+	}
+}
+
+func TestForEachConcurrently_error(t *testing.T) {
+	expected := errors.New("boom")
+
+	err := forEachConcurrently(context.Background(), 100, 2, func(ctx context.Context, i int) error {
+		if i == 5 {
+			return expected
+		}
+		return nil
+	})
+
+	if !errors.Is(err, expected) {
+		t.Errorf("expected error %q, got %v", expected, err)
+	}
+}